@@ -0,0 +1,469 @@
+package chttp
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// Source 表示字段值的来源
+type Source string
+
+const (
+	SourceQuery  Source = "query"
+	SourceHeader Source = "header"
+	SourcePath   Source = "path"
+	SourceBody   Source = "body"
+	SourceForm   Source = "form"
+	SourceCookie Source = "cookie"
+
+	// SourceContext 表示值来自请求上下文（ctx 标签），始终优先于客户端可控的来源
+	SourceContext Source = "context"
+
+	// SourceDefault 表示值来自 default 标签，不参与优先级排序
+	SourceDefault Source = "default"
+)
+
+// defaultSourcePriority 默认来源优先级（从低到高）：Query < Cookie < Header < Form < Body < URL
+var defaultSourcePriority = []Source{SourceQuery, SourceCookie, SourceHeader, SourceForm, SourceBody, SourcePath}
+
+const formMediaType = "application/x-www-form-urlencoded"
+
+// DefaultMaxBodySize 默认的请求体大小上限（10MB），可以通过 WithMaxBodySize 或 BodySizeLimiter 调整
+const DefaultMaxBodySize int64 = 10 << 20
+
+// BodySizeLimiter 由需要单独设置请求体大小上限的请求类型实现（如文件上传），返回 <= 0 表示不限制
+type BodySizeLimiter interface {
+	MaxBodySize() int64
+}
+
+// defaultTimeFormats 默认支持的时间格式，时间戳（秒/毫秒）始终支持
+var defaultTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006.01.02 15:04:05",
+	"2006.01.02",
+}
+
+// TagNames 定义 Binder 使用的各个标签名，为空的字段沿用默认值
+type TagNames struct {
+	Validate string // 校验规则，默认 "v"
+	Recurse  string // 是否递归解析嵌套结构体，默认 "cv"
+	Query    string // Query 参数，默认 "param"
+	Header   string // 请求头，默认 "header"
+	Form     string // 表单字段，默认 "form"
+	Cookie   string // Cookie，默认 "cookie"
+	Context  string // 请求上下文，默认 "ctx"
+	URL      string // go-chi 路径参数，默认 "url"
+	Default  string // 默认值，默认 "default"
+	RawJSON  string // 从另一个字符串字段解析 JSON，默认 "rawJson"
+	Time     string // 时间格式、时区与时间戳精度，默认 "time"
+	Body     string // 将整个请求体绑定到该字段，默认 "body"
+}
+
+var defaultTagNames = TagNames{
+	Validate: "v",
+	Recurse:  "cv",
+	Query:    "param",
+	Header:   "header",
+	Form:     "form",
+	Cookie:   "cookie",
+	Context:  "ctx",
+	URL:      "url",
+	Default:  "default",
+	RawJSON:  "rawJson",
+	Time:     "time",
+	Body:     "body",
+}
+
+// BodyDecoder 将请求体解码到 v（指向目标结构体的指针，也可以指向切片、map 或基础类型），
+// 并把请求体中出现过的字段路径（Go 字段名以 "." 连接，如 "BaseReq.TraceId"）记录到 present 中，
+// 被记录的字段不会再被低优先级的来源或 default 标签覆盖
+type BodyDecoder interface {
+	Decode(r io.Reader, v any, present map[string]bool) error
+}
+
+// BodyDecoderFunc 将普通函数作为 BodyDecoder 使用
+type BodyDecoderFunc func(r io.Reader, v any, present map[string]bool) error
+
+// Decode 调用 fn(r, v, present)
+func (fn BodyDecoderFunc) Decode(r io.Reader, v any, present map[string]bool) error {
+	return fn(r, v, present)
+}
+
+// BodyKeyTagger 由 BodyDecoder 实现，返回请求体中键名使用的标签（如 xml、yaml），校验错误按该标签命名请求体中的字段；
+// 未实现时按 json 标签命名
+type BodyKeyTagger interface {
+	BodyKeyTag() string
+}
+
+// bodyKeyTag 返回解码器声明的键名标签，未声明时为 json
+func bodyKeyTag(d BodyDecoder) string {
+	if tagger, ok := d.(BodyKeyTagger); ok {
+		if tag := tagger.BodyKeyTag(); tag != "" {
+			return tag
+		}
+	}
+	return "json"
+}
+
+// Binder 保存请求绑定的全部配置，构建后可在多个 handler 之间共享
+type Binder struct {
+	tags        TagNames
+	validate    *validator.Validate
+	timeFormats []string
+	maxBodySize int64
+	priority    []Source
+	decoders    map[string]BodyDecoder
+	formParam   bool
+
+	reusableBody bool
+	strictJSON   bool
+
+	typeDecoders map[reflect.Type]TypeDecoder
+
+	timeLocation *time.Location
+	strictTime   bool
+	defaultTime  *timeFormat // 由 timeFormats、timeLocation 与 strictTime 组成，没有 time 标签的字段使用
+
+	contextValues map[string]ContextExtractor
+
+	multipartMaxMemory int64
+	plans              sync.Map // reflect.Type -> *typePlan
+	jsonKinds          sync.Map // reflect.Type -> jsonValueKind
+	ctxTypes           sync.Map // reflect.Type -> bool，类型的任何层级是否带 ctx 标签的字段
+}
+
+// Option 用于配置 Binder
+type Option func(*Binder)
+
+// WithTagNames 替换默认的标签名，未设置的字段保持默认
+func WithTagNames(tags TagNames) Option {
+	return func(b *Binder) {
+		if tags.Validate != "" {
+			b.tags.Validate = tags.Validate
+		}
+		if tags.Recurse != "" {
+			b.tags.Recurse = tags.Recurse
+		}
+		if tags.Query != "" {
+			b.tags.Query = tags.Query
+		}
+		if tags.Header != "" {
+			b.tags.Header = tags.Header
+		}
+		if tags.Form != "" {
+			b.tags.Form = tags.Form
+		}
+		if tags.Cookie != "" {
+			b.tags.Cookie = tags.Cookie
+		}
+		if tags.Context != "" {
+			b.tags.Context = tags.Context
+		}
+		if tags.URL != "" {
+			b.tags.URL = tags.URL
+		}
+		if tags.Default != "" {
+			b.tags.Default = tags.Default
+		}
+		if tags.RawJSON != "" {
+			b.tags.RawJSON = tags.RawJSON
+		}
+		if tags.Time != "" {
+			b.tags.Time = tags.Time
+		}
+		if tags.Body != "" {
+			b.tags.Body = tags.Body
+		}
+	}
+}
+
+// WithValidator 使用外部构建的校验器，调用方需要自行设置其标签名
+func WithValidator(v *validator.Validate) Option {
+	return func(b *Binder) {
+		b.validate = v
+	}
+}
+
+// WithTimeFormats 替换默认的时间格式列表
+func WithTimeFormats(formats ...string) Option {
+	return func(b *Binder) {
+		b.timeFormats = formats
+	}
+}
+
+// WithMaxBodySize 限制请求体（包括 multipart 上传）的最大字节数，默认为 DefaultMaxBodySize，<= 0 表示不限制
+func WithMaxBodySize(n int64) Option {
+	return func(b *Binder) {
+		b.maxBodySize = n
+	}
+}
+
+// WithReusableBody 读取请求体后将 r.Body 替换为可重复读取的副本。
+// 默认不保留请求体：JSON 等请求体按流解码，不会整体读入内存
+func WithReusableBody() Option {
+	return func(b *Binder) {
+		b.reusableBody = true
+	}
+}
+
+// WithSourcePriority 设置来源优先级（从低到高），未列出的来源优先级最低，彼此之间保持默认的顺序
+func WithSourcePriority(sources ...Source) Option {
+	return func(b *Binder) {
+		b.priority = withUnlistedSources(sources)
+	}
+}
+
+// withUnlistedSources 将 sources 中未列出的来源按默认顺序放在最前面（优先级最低）
+func withUnlistedSources(sources []Source) []Source {
+	priority := make([]Source, 0, len(defaultSourcePriority)+len(sources))
+	for _, s := range defaultSourcePriority {
+		if !slices.Contains(sources, s) {
+			priority = append(priority, s)
+		}
+	}
+	return append(priority, sources...)
+}
+
+// WithFormParamFallback 字段没有 form 标签时，使用 param 标签的名称读取表单字段
+func WithFormParamFallback() Option {
+	return func(b *Binder) {
+		b.formParam = true
+	}
+}
+
+// WithBodyDecoder 为指定的 media type 注册请求体解码器，pattern 的写法见 RegisterBodyDecoder
+func WithBodyDecoder(pattern string, d BodyDecoder) Option {
+	return func(b *Binder) {
+		b.decoders[strings.ToLower(pattern)] = d
+	}
+}
+
+// NewBinder 创建 Binder，未传入的配置使用与包级函数一致的默认值
+func NewBinder(opts ...Option) *Binder {
+	b := &Binder{
+		tags:        defaultTagNames,
+		timeFormats: defaultTimeFormats,
+		maxBodySize: DefaultMaxBodySize,
+		priority:    defaultSourcePriority,
+		decoders:    make(map[string]BodyDecoder),
+
+		typeDecoders: builtinTypeDecoders(),
+
+		contextValues: make(map[string]ContextExtractor),
+
+		multipartMaxMemory: defaultMultipartMaxMemory,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.defaultTime = &timeFormat{layouts: b.timeFormats, loc: b.timeLocation, strict: b.strictTime}
+	if b.validate == nil {
+		b.validate = validator.New()
+		b.validate.SetTagName(b.tags.Validate)
+		_ = RegisterFileValidations(b.validate)
+	}
+	defaults := map[string]BodyDecoder{
+		jsonMediaType:        &jsonBodyDecoder{binder: b},
+		"application/*+json": &jsonBodyDecoder{binder: b},
+		xmlMediaType:         &xmlBodyDecoder{binder: b},
+		"text/xml":           &xmlBodyDecoder{binder: b},
+		"application/*+xml":  &xmlBodyDecoder{binder: b},
+	}
+	for pattern, d := range defaults {
+		if _, ok := b.decoders[pattern]; !ok {
+			b.decoders[pattern] = d
+		}
+	}
+	return b
+}
+
+var defaultBinder = NewBinder()
+
+// DefaultBinder 返回包级函数 Valid、ParseWithValidation、ReadRequestBody 使用的 Binder
+func DefaultBinder() *Binder {
+	return defaultBinder
+}
+
+// Bind 使用 b 解析并校验请求，返回值与 Valid 一致
+func Bind[T any](b *Binder, r *http.Request) (T, ParserResult, error) {
+	req, validation, err := BindWithValidation[T](b, r)
+	if err != nil {
+		return req, ParserResultError, err
+	} else if validation.Valid == nil || *validation.Valid == false {
+		return req, ParserResultNotVerified, validation.Error
+	}
+	return req, ParserResultSuccess, nil
+}
+
+// BindBody 使用 b 的请求体大小限制与为 application/json 注册的解码器将 JSON 请求体解析为 T，
+// 读取后 r.Body 即被消耗，开启 WithReusableBody 时才可重复读取
+func BindBody[T any](b *Binder, r *http.Request) (*T, error) {
+	var t T
+	if err := b.checkTags(reflect.TypeOf(&t).Elem()); err != nil {
+		return nil, err
+	}
+	body, err := b.readBody(r, b.bodyLimit(&t))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.decoders[jsonMediaType].Decode(bytes.NewReader(body), &t, make(map[string]bool)); err != nil {
+		return nil, asDecodeError(jsonMediaType, err)
+	}
+	return &t, nil
+}
+
+// readBody 读取整个请求体（受 limit 限制），开启 WithReusableBody 时替换为可重复读取的 r.Body
+func (b *Binder) readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(limitBody(r, limit))
+	if err != nil {
+		return nil, bodyReadError(err)
+	}
+	if b.reusableBody {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return body, nil
+}
+
+// limitBody 返回最多读取 limit 字节的请求体，超过时读取返回 *http.MaxBytesError，limit <= 0 表示不限制
+func limitBody(r *http.Request, limit int64) io.Reader {
+	if limit <= 0 {
+		return r.Body
+	}
+	return http.MaxBytesReader(nil, r.Body, limit)
+}
+
+// bodyReadError 将请求体超过限制的读取错误转换为 BodyTooLargeError，
+// 上游使用 http.MaxBytesReader 时同样按请求体过大处理
+func bodyReadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &BodyTooLargeError{Limit: maxBytesErr.Limit}
+	}
+	return err
+}
+
+// bodyLimit 返回解析到 v 时的请求体大小上限，v 实现 BodySizeLimiter 时优先使用其返回值
+func (b *Binder) bodyLimit(v any) int64 {
+	if limiter, ok := v.(BodySizeLimiter); ok {
+		return limiter.MaxBodySize()
+	}
+	return b.maxBodySize
+}
+
+// bodyDecoder 根据 Content-Type 选择请求体解码器，未设置 Content-Type 时按 JSON 处理
+func (b *Binder) bodyDecoder(contentType string) (BodyDecoder, string, error) {
+	if contentType == "" {
+		return b.decoders[jsonMediaType], jsonMediaType, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, contentType, &UnsupportedMediaTypeError{MediaType: contentType}
+	}
+	if d := b.LookupBodyDecoder(mediaType); d != nil {
+		return d, mediaType, nil
+	}
+	return nil, mediaType, &UnsupportedMediaTypeError{MediaType: mediaType}
+}
+
+// LookupBodyDecoder 返回处理 mediaType 的解码器，没有时返回 nil。按从具体到宽泛的顺序匹配注册的 media type：
+// 完整类型（application/vnd.api+json）、结构化语法后缀（application/*+json、*/*+json）、主类型（application/*）、*/*。
+// 包装已有解码器的 BodyDecoder 可以在注册之前用它取得原来的解码器
+func (b *Binder) LookupBodyDecoder(mediaType string) BodyDecoder {
+	mediaType = strings.ToLower(mediaType)
+	if d, ok := b.decoders[mediaType]; ok {
+		return d
+	}
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	candidates := []string{typ + "/*", "*/*"}
+	if i := strings.LastIndexByte(subtype, '+'); i >= 0 {
+		suffix := subtype[i:]
+		candidates = append([]string{typ + "/*" + suffix, "*/*" + suffix}, candidates...)
+	}
+	for _, pattern := range candidates {
+		if d, ok := b.decoders[pattern]; ok {
+			return d
+		}
+	}
+	return nil
+}
+
+// RegisterBodyDecoder 在 Binder 上为 media type 注册请求体解码器，应在开始处理请求之前完成。
+// pattern 可以是完整类型（application/msgpack）、带结构化语法后缀的通配（application/*+json、*/*+cbor）、
+// 主类型通配（text/*）或 */*，同名的注册会替换已有的解码器（包括内置的 JSON 与 XML 解码器）
+func (b *Binder) RegisterBodyDecoder(pattern string, d BodyDecoder) {
+	b.decoders[strings.ToLower(pattern)] = d
+}
+
+// RegisterBodyDecoder 在默认 Binder 上为 media type 注册请求体解码器
+func RegisterBodyDecoder(pattern string, d BodyDecoder) {
+	defaultBinder.RegisterBodyDecoder(pattern, d)
+}
+
+// isFormContentType 判断 Content-Type 是否为 application/x-www-form-urlencoded
+func isFormContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == formMediaType
+}
+
+// sourceRank 返回来源在优先级列表中的位置，未列出的来源返回 -1
+func (b *Binder) sourceRank(s Source) int {
+	for i, p := range b.priority {
+		if p == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Validator 返回 Binder 共享的校验器
+func (b *Binder) Validator() *validator.Validate {
+	return b.validate
+}
+
+// RegisterValidation 注册自定义校验规则，之后可在校验标签中使用 tag，
+// 与 validator 一样，注册应在开始处理请求之前完成
+func (b *Binder) RegisterValidation(tag string, fn validator.Func, callValidationEvenIfNull ...bool) error {
+	return b.validate.RegisterValidation(tag, fn, callValidationEvenIfNull...)
+}
+
+// RegisterStructValidation 为 types 注册结构体级别的校验
+func (b *Binder) RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	b.validate.RegisterStructValidation(fn, types...)
+}
+
+// RegisterAlias 注册校验规则别名，如 RegisterAlias("tenant", "required,len=8")
+func (b *Binder) RegisterAlias(alias, tags string) {
+	b.validate.RegisterAlias(alias, tags)
+}
+
+// RegisterValidation 在默认 Binder 上注册自定义校验规则
+func RegisterValidation(tag string, fn validator.Func, callValidationEvenIfNull ...bool) error {
+	return defaultBinder.RegisterValidation(tag, fn, callValidationEvenIfNull...)
+}
+
+// RegisterStructValidation 在默认 Binder 上注册结构体级别的校验
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	defaultBinder.RegisterStructValidation(fn, types...)
+}
+
+// RegisterAlias 在默认 Binder 上注册校验规则别名
+func RegisterAlias(alias, tags string) {
+	defaultBinder.RegisterAlias(alias, tags)
+}
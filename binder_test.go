@@ -0,0 +1,324 @@
+package chttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+// withURLParams 模拟 go-chi 路由，为请求设置路径参数
+func withURLParams(r *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestBinderCustomTagNames 测试自定义标签名
+func TestBinderCustomTagNames(t *testing.T) {
+	type testStruct struct {
+		Page  int    `query:"page" def:"1"`
+		Trace string `hdr:"X-Trace" validate:"required"`
+	}
+	b := NewBinder(WithTagNames(TagNames{
+		Validate: "validate",
+		Query:    "query",
+		Header:   "hdr",
+		Default:  "def",
+	}))
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Trace", "trace-1")
+	result, parserResult, err := Bind[testStruct](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	if result.Page != 1 || result.Trace != "trace-1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	req, _ = http.NewRequest("GET", "/test?page=3", nil)
+	result, parserResult, _ = Bind[testStruct](b, req)
+	if parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+	if result.Page != 3 {
+		t.Errorf("expected Page to be 3, got %v", result.Page)
+	}
+}
+
+// TestBinderSourcePriority 测试默认与自定义的来源优先级
+func TestBinderSourcePriority(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name" param:"name" header:"X-Name" url:"name"`
+	}
+	newReq := func() *http.Request {
+		req, _ := http.NewRequest("POST", "/test?name=query", bytes.NewBufferString(`{"name":"body"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Name", "header")
+		return withURLParams(req, map[string]string{"name": "path"})
+	}
+
+	tests := []struct {
+		name     string
+		binder   *Binder
+		expected string
+	}{
+		{"default_path_wins", NewBinder(), "path"},
+		{"body_highest", NewBinder(WithSourcePriority(SourceQuery, SourcePath, SourceHeader, SourceBody)), "body"},
+		{"header_over_body", NewBinder(WithSourcePriority(SourceBody, SourceQuery, SourceHeader)), "header"},
+		{"query_highest", NewBinder(WithSourcePriority(SourceBody, SourceHeader, SourcePath, SourceQuery)), "query"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := Bind[testStruct](tt.binder, newReq())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Name != tt.expected {
+				t.Errorf("expected Name to be %q, got %q", tt.expected, result.Name)
+			}
+		})
+	}
+}
+
+// TestBinderUnlistedSources 测试 WithSourcePriority 中未列出的来源仍会读取，优先级低于列出的来源
+func TestBinderUnlistedSources(t *testing.T) {
+	type testStruct struct {
+		TraceId string `header:"traceId" v:"required"`
+		Name    string `param:"name" header:"X-Name"`
+	}
+	req, _ := http.NewRequest("GET", "/test?name=query", nil)
+	req.Header.Set("traceId", "t-1")
+	req.Header.Set("X-Name", "header")
+	result, _, err := Bind[testStruct](NewBinder(WithSourcePriority(SourceQuery, SourcePath)), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TraceId != "t-1" || result.Name != "query" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// TestBinderTimeFormats 测试自定义时间格式
+func TestBinderTimeFormats(t *testing.T) {
+	type testStruct struct {
+		Day time.Time `param:"day"`
+	}
+	b := NewBinder(WithTimeFormats("02-01-2006"))
+
+	req, _ := http.NewRequest("GET", "/test?day=15-03-2024", nil)
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Day.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("expected 2024-03-15, got %v", result.Day)
+	}
+
+	// 默认格式不再生效
+	req, _ = http.NewRequest("GET", "/test?day=2024-03-15", nil)
+	if _, parserResult, _ := Bind[testStruct](b, req); parserResult != ParserResultError {
+		t.Errorf("expected ParserResultError, got %v", parserResult)
+	}
+}
+
+// TestBinderMaxBodySize 测试请求体大小限制
+func TestBinderMaxBodySize(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	b := NewBinder(WithMaxBodySize(16))
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"ok"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if result, _, err := Bind[testStruct](b, req); err != nil || result.Name != "ok" {
+		t.Fatalf("expected Name=ok, got %+v, err %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"too long for limit"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, parserResult, err := Bind[testStruct](b, req); err == nil || parserResult != ParserResultError {
+		t.Errorf("expected body size error, got %v, %v", parserResult, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"too long for limit"}`))
+	if _, err := BindBody[testStruct](b, req); err == nil {
+		t.Error("expected BindBody to reject oversized body")
+	}
+}
+
+// TestBinderSharedValidator 测试传入外部校验器
+func TestBinderSharedValidator(t *testing.T) {
+	type testStruct struct {
+		Code string `param:"code" v:"upper"`
+	}
+	validate := validator.New()
+	validate.SetTagName("v")
+	if err := validate.RegisterValidation("upper", func(fl validator.FieldLevel) bool {
+		return strings.ToUpper(fl.Field().String()) == fl.Field().String()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	b := NewBinder(WithValidator(validate))
+
+	req, _ := http.NewRequest("GET", "/test?code=ABC", nil)
+	if _, parserResult, err := Bind[testStruct](b, req); parserResult != ParserResultSuccess {
+		t.Errorf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+	req, _ = http.NewRequest("GET", "/test?code=abc", nil)
+	if _, parserResult, _ := Bind[testStruct](b, req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+}
+
+// lineDecoder 以 "key=value" 行格式解码请求体，仅用于测试
+type lineDecoder struct{}
+
+func (lineDecoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	target := v.(*struct {
+		Name string `param:"name" default:"anonymous"`
+	})
+	for _, line := range strings.Split(string(body), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok && key == "name" {
+			target.Name = value
+			present["Name"] = true
+		}
+	}
+	return nil
+}
+
+// TestBinderBodyDecoder 测试按 Content-Type 注册请求体解码器
+func TestBinderBodyDecoder(t *testing.T) {
+	type testStruct = struct {
+		Name string `param:"name" default:"anonymous"`
+	}
+	b := NewBinder(WithBodyDecoder("text/plain", lineDecoder{}))
+
+	req, _ := http.NewRequest("POST", "/test?name=query", bytes.NewBufferString("name=plain"))
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "plain" {
+		t.Errorf("expected Name to be 'plain', got %q", result.Name)
+	}
+
+	// 默认 Binder 没有注册 text/plain
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString("name=plain"))
+	req.Header.Set("Content-Type", "text/plain")
+	if _, parserResult, _ := Valid[testStruct](req); parserResult != ParserResultError {
+		t.Errorf("expected ParserResultError, got %v", parserResult)
+	}
+}
+
+// TestBodyDecoderRegistry 测试 media type 的通配匹配顺序
+func TestBodyDecoderRegistry(t *testing.T) {
+	named := func(name string) BodyDecoder {
+		return BodyDecoderFunc(func(r io.Reader, v any, present map[string]bool) error {
+			*v.(*string) = name
+			return nil
+		})
+	}
+	b := NewBinder(
+		WithBodyDecoder("application/vnd.acme+json", named("exact")),
+		WithBodyDecoder("text/*", named("text")),
+		WithBodyDecoder("*/*+avro", named("avro")),
+	)
+	b.RegisterBodyDecoder("*/*", named("any"))
+
+	tests := map[string]string{
+		"application/vnd.acme+json": "exact",
+		"text/csv; charset=utf-8":   "text",
+		"application/vnd.acme+avro": "avro",
+		"application/octet-stream":  "any",
+		"Application/VND.ACME+JSON": "exact",
+	}
+	for contentType, expected := range tests {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("body"))
+		req.Header.Set("Content-Type", contentType)
+		result, _, err := Bind[string](b, req)
+		if err != nil || result != expected {
+			t.Errorf("%s: expected %q, got %q, %v", contentType, expected, result, err)
+		}
+	}
+
+	// 内置的 JSON 与 XML 解码器处理结构化语法后缀
+	type testStruct struct {
+		Name string `json:"name" xml:"name"`
+	}
+	for contentType, body := range map[string]string{
+		"application/problem+json":      `{"name":"json"}`,
+		"application/vnd.github.v3+xml": `<r><name>xml</name></r>`,
+	} {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		if result, _, err := Bind[testStruct](b, req); err != nil || result.Name == "" {
+			t.Errorf("%s: unexpected result %+v, %v", contentType, result, err)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("x"))
+	req.Header.Set("Content-Type", "application/x-unknown")
+	if _, _, err := Valid[testStruct](req); StatusCode(err) != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status 415, got %d: %v", StatusCode(err), err)
+	}
+}
+
+type uploadRequest struct {
+	Name string `json:"name"`
+}
+
+func (uploadRequest) MaxBodySize() int64 { return 64 }
+
+// TestBodySizeLimiter 测试请求类型通过 BodySizeLimiter 覆盖 Binder 的大小限制
+func TestBodySizeLimiter(t *testing.T) {
+	b := NewBinder(WithMaxBodySize(16))
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"longer than sixteen bytes"}`))
+	if result, _, err := Bind[uploadRequest](b, req); err != nil || result.Name != "longer than sixteen bytes" {
+		t.Fatalf("expected per-type limit to apply, got %+v, err %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader(`{"name":"`+strings.Repeat("x", 100)+`"}`))
+	_, _, err := Bind[uploadRequest](b, req)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) || StatusCode(err) != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected *BodyTooLargeError with status 413, got %T: %v", err, err)
+	}
+}
+
+// TestDefaultMaxBodySize 测试未设置 WithMaxBodySize 时使用默认上限
+func TestDefaultMaxBodySize(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	body := `{"name":"` + strings.Repeat("x", int(DefaultMaxBodySize)) + `"}`
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(body))
+	_, _, err := Valid[testStruct](req)
+	if StatusCode(err) != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d: %v", StatusCode(err), err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader(body))
+	if _, _, err = Bind[testStruct](NewBinder(WithMaxBodySize(0)), req); err != nil {
+		t.Errorf("expected unlimited body to be accepted, got %v", err)
+	}
+}
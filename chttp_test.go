@@ -1675,7 +1675,10 @@ func TestDAStructValidation(t *testing.T) {
 				}
 			}
 
-			// 测试 Valid 函数
+			// 测试 Valid 函数，请求体默认只能读取一次，需要重新设置
+			if tt.jsonBody != "" {
+				req.Body = io.NopCloser(bytes.NewBuffer([]byte(tt.jsonBody)))
+			}
 			_, parserResult, err2 := Valid[DA](req)
 			if tt.shouldPass {
 				if err2 != nil {
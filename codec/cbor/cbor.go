@@ -0,0 +1,60 @@
+// Package cbor 为 chttp 提供 CBOR 请求体解码器，导入本包即在默认 Binder 上注册，
+// 自行创建的 Binder 使用 Register 注册
+package cbor
+
+import (
+	"io"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/kuah/chttp"
+)
+
+// MediaType CBOR 请求体的 media type，报告错误时使用
+const MediaType = "application/cbor"
+
+// MediaTypes 注册的 media type
+var MediaTypes = []string{MediaType, "application/*+cbor"}
+
+// decMode 解码到通用值的设置：标签 0、1 的时间解码为 time.Time
+var decMode, _ = fxcbor.DecOptions{TimeTagToAny: fxcbor.TimeTagToTime}.DecMode()
+
+func init() {
+	Register(chttp.DefaultBinder())
+}
+
+// Register 在 b 上为 MediaTypes 注册 CBOR 解码器
+func Register(b *chttp.Binder) {
+	d := NewDecoder(b)
+	for _, pattern := range MediaTypes {
+		b.RegisterBodyDecoder(pattern, d)
+	}
+}
+
+// NewDecoder 返回使用 b 的配置解码的 CBOR 解码器，键名使用 cbor 标签（整数键按十进制字符串匹配），
+// 没有 cbor 标签的字段使用 json 标签的名称
+func NewDecoder(b *chttp.Binder) chttp.BodyDecoder {
+	return &decoder{binder: b}
+}
+
+type decoder struct {
+	binder *chttp.Binder
+}
+
+// BodyKeyTag 请求体的键名使用 cbor 标签，校验错误按该标签命名字段
+func (d *decoder) BodyKeyTag() string {
+	return "cbor"
+}
+
+// Decode 将 CBOR 转换为 JSON 后按 JSON 的规则解码，时间标签（0、1）不受字段 time 标签的格式限制，
+// 字节串按 base64 写入 []byte 字段
+func (d *decoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := decMode.Unmarshal(body, &doc); err != nil {
+		return &chttp.DecodeError{MediaType: MediaType, Err: err}
+	}
+	return d.binder.DecodeGeneric(doc, v, present, chttp.BodyFormat{MediaType: MediaType, KeyTag: "cbor", NativeTime: true})
+}
@@ -0,0 +1,68 @@
+package cbor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/kuah/chttp"
+)
+
+type deviceReport struct {
+	DeviceId  string    `url:"deviceId" v:"required"`
+	Firmware  string    `header:"X-Firmware" default:"unknown"`
+	Battery   int       `json:"battery" v:"gte=0,lte=100"`
+	Interval  int       `msgpack:"interval" cbor:"3,keyasint" default:"60"`
+	Reported  time.Time `json:"reported" time:"unix"`
+	Payload   []byte    `json:"payload"`
+	Readings  []float64 `json:"readings"`
+	Threshold *float64  `json:"threshold"`
+}
+
+// newDeviceRequest 构造带路径参数的二进制请求体
+func newDeviceRequest(contentType string, body []byte) *http.Request {
+	req, _ := http.NewRequest("POST", "/devices/d-1/report", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("deviceId", "d-1")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestCBORBinding 测试 CBOR 请求体，时间标签（0、1）与整数键
+func TestCBORBinding(t *testing.T) {
+	reported := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	for _, mode := range []fxcbor.EncOptions{
+		{Time: fxcbor.TimeRFC3339Nano, TimeTag: fxcbor.EncTagRequired},
+		{Time: fxcbor.TimeUnix, TimeTag: fxcbor.EncTagRequired},
+	} {
+		enc, _ := mode.EncMode()
+		body, _ := enc.Marshal(map[any]any{
+			"battery":  80,
+			"reported": reported,
+			"payload":  []byte("raw"),
+			3:          30,
+		})
+		result, _, err := chttp.Valid[deviceReport](newDeviceRequest("application/cbor", body))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Battery != 80 || result.Interval != 30 || string(result.Payload) != "raw" || result.Firmware != "unknown" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if !result.Reported.Equal(reported) {
+			t.Errorf("expected %v, got %v", reported, result.Reported)
+		}
+	}
+
+	body, _ := fxcbor.Marshal(map[string]any{"battery": 1})
+	_, _, err := chttp.Valid[deviceReport](newDeviceRequest("application/cbor", append(body, 0x00)))
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/cbor" {
+		t.Errorf("expected *chttp.DecodeError for trailing data, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,166 @@
+// Package msgpack 为 chttp 提供 MessagePack 请求体解码器，导入本包即在默认 Binder 上注册，
+// 自行创建的 Binder 使用 Register 注册
+package msgpack
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/kuah/chttp"
+	vmsgpack "github.com/vmihailenco/msgpack/v5"
+)
+
+// MediaType MessagePack 请求体的 media type，报告错误时使用
+const MediaType = "application/msgpack"
+
+// maxDepth 数组与 map 的最大嵌套层数
+const maxDepth = 1000
+
+// MediaTypes 注册的 media type
+var MediaTypes = []string{MediaType, "application/x-msgpack", "application/vnd.msgpack"}
+
+func init() {
+	Register(chttp.DefaultBinder())
+}
+
+// Register 在 b 上为 MediaTypes 注册 MessagePack 解码器
+func Register(b *chttp.Binder) {
+	d := NewDecoder(b)
+	for _, pattern := range MediaTypes {
+		b.RegisterBodyDecoder(pattern, d)
+	}
+}
+
+// NewDecoder 返回使用 b 的配置解码的 MessagePack 解码器，键名使用 msgpack 标签，没有 msgpack 标签的字段使用 json 标签的名称
+func NewDecoder(b *chttp.Binder) chttp.BodyDecoder {
+	return &decoder{binder: b}
+}
+
+type decoder struct {
+	binder *chttp.Binder
+}
+
+// BodyKeyTag 请求体的键名使用 msgpack 标签，校验错误按该标签命名字段
+func (d *decoder) BodyKeyTag() string {
+	return "msgpack"
+}
+
+// Decode 将 MessagePack 转换为 JSON 后按 JSON 的规则解码，时间戳扩展类型（-1）不受字段 time 标签的格式限制，
+// 二进制数据按 base64 写入 []byte 字段
+func (d *decoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	// 解码到 any 时按头部声明的长度预先分配，先确认每个长度都不超过剩余的字节数
+	if err := checkLengths(body); err != nil {
+		return &chttp.DecodeError{MediaType: MediaType, Err: err}
+	}
+	reader := bytes.NewReader(body)
+	dec := vmsgpack.NewDecoder(reader)
+	// 键可以不是字符串，由 DecodeGeneric 统一转换
+	dec.SetMapDecoder(func(dec *vmsgpack.Decoder) (any, error) {
+		return dec.DecodeUntypedMap()
+	})
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return &chttp.DecodeError{MediaType: MediaType, Err: err}
+	}
+	if reader.Len() > 0 {
+		return &chttp.DecodeError{MediaType: MediaType, Err: fmt.Errorf("invalid data after top-level value at offset %d", len(body)-reader.Len())}
+	}
+	return d.binder.DecodeGeneric(doc, v, present, chttp.BodyFormat{MediaType: MediaType, KeyTag: "msgpack", NativeTime: true})
+}
+
+// checkLengths 不分配内存地遍历 body 中的第一个值，确认数组与 map 的元素个数（每个元素至少占一个字节）
+// 以及字符串、二进制与扩展类型的长度都不超过剩余的字节数，且嵌套不超过 maxDepth 层
+func checkLengths(body []byte) error {
+	remaining := []int{1} // 每一层尚未读取的值的个数
+	off := 0
+	for len(remaining) > 0 {
+		top := len(remaining) - 1
+		if remaining[top] == 0 {
+			remaining = remaining[:top]
+			continue
+		}
+		remaining[top]--
+		if off >= len(body) {
+			return io.ErrUnexpectedEOF
+		}
+		start := off
+		c := body[off]
+		off++
+		var items, data, lenSize int
+		switch {
+		case c <= 0x7f || c >= 0xe0: // positive/negative fixint
+		case c <= 0x8f: // fixmap
+			items = 2 * int(c&0x0f)
+		case c <= 0x9f: // fixarray
+			items = int(c & 0x0f)
+		case c <= 0xbf: // fixstr
+			data = int(c & 0x1f)
+		case c == 0xc0 || c == 0xc2 || c == 0xc3: // nil, false, true
+		case c == 0xc4 || c == 0xd9: // bin8, str8
+			lenSize = 1
+		case c == 0xc5 || c == 0xda: // bin16, str16
+			lenSize = 2
+		case c == 0xc6 || c == 0xdb: // bin32, str32
+			lenSize = 4
+		case c == 0xc7 || c == 0xc8 || c == 0xc9: // ext8, ext16, ext32：长度之后还有 1 字节的类型
+			lenSize = 1 << (c - 0xc7)
+			data = 1
+		case c == 0xca || c == 0xce || c == 0xd2: // float32, uint32, int32
+			data = 4
+		case c == 0xcb || c == 0xcf || c == 0xd3: // float64, uint64, int64
+			data = 8
+		case c == 0xcc || c == 0xd0: // uint8, int8
+			data = 1
+		case c == 0xcd || c == 0xd1: // uint16, int16
+			data = 2
+		case c >= 0xd4 && c <= 0xd8: // fixext1 ~ fixext16
+			data = 1 + 1<<(c-0xd4)
+		case c == 0xdc || c == 0xde: // array16, map16
+			lenSize = 2
+		case c == 0xdd || c == 0xdf: // array32, map32
+			lenSize = 4
+		default:
+			return fmt.Errorf("invalid code %x at offset %d", c, start)
+		}
+		if lenSize > 0 {
+			if len(body)-off < lenSize {
+				return io.ErrUnexpectedEOF
+			}
+			var n int
+			switch lenSize {
+			case 1:
+				n = int(body[off])
+			case 2:
+				n = int(binary.BigEndian.Uint16(body[off:]))
+			default:
+				n = int(binary.BigEndian.Uint32(body[off:]))
+			}
+			off += lenSize
+			switch c {
+			case 0xdc, 0xdd:
+				items = n
+			case 0xde, 0xdf:
+				items = 2 * n
+			default:
+				data += n
+			}
+		}
+		if data > len(body)-off || items > len(body)-off {
+			return fmt.Errorf("length at offset %d exceeds the remaining %d bytes", start, len(body)-off)
+		}
+		off += data
+		if items > 0 {
+			if len(remaining) > maxDepth {
+				return fmt.Errorf("nesting exceeds %d levels at offset %d", maxDepth, start)
+			}
+			remaining = append(remaining, items)
+		}
+	}
+	return nil
+}
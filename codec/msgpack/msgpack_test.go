@@ -0,0 +1,120 @@
+package msgpack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kuah/chttp"
+	vmsgpack "github.com/vmihailenco/msgpack/v5"
+)
+
+type deviceReport struct {
+	DeviceId  string    `url:"deviceId" v:"required"`
+	Firmware  string    `header:"X-Firmware" default:"unknown"`
+	Battery   int       `json:"battery" v:"gte=0,lte=100"`
+	Interval  int       `msgpack:"interval" cbor:"3,keyasint" default:"60"`
+	Reported  time.Time `json:"reported" time:"unix"`
+	Payload   []byte    `json:"payload"`
+	Readings  []float64 `json:"readings"`
+	Threshold *float64  `json:"threshold"`
+}
+
+// newDeviceRequest 构造带路径参数的二进制请求体
+func newDeviceRequest(contentType string, body []byte) *http.Request {
+	req, _ := http.NewRequest("POST", "/devices/d-1/report", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("deviceId", "d-1")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestMsgpackBinding 测试 MessagePack 请求体与路径参数、header、默认值合并，时间戳扩展类型不受 time 标签限制
+func TestMsgpackBinding(t *testing.T) {
+	reported := time.Date(2024, 3, 1, 8, 0, 0, 123456789, time.UTC)
+	body, _ := vmsgpack.Marshal(map[string]any{
+		"battery":  0,
+		"reported": reported,
+		"payload":  []byte{0x01, 0xff},
+		"readings": []any{1.5, 2},
+		"ignored":  map[int]string{1: "a"},
+	})
+	req := newDeviceRequest("application/msgpack", body)
+	req.Header.Set("X-Firmware", "1.2.0")
+	result, _, err := chttp.Valid[deviceReport](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DeviceId != "d-1" || result.Firmware != "1.2.0" || result.Battery != 0 || result.Interval != 60 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !result.Reported.Equal(reported) {
+		t.Errorf("expected %v, got %v", reported, result.Reported)
+	}
+	if !bytes.Equal(result.Payload, []byte{0x01, 0xff}) || len(result.Readings) != 2 || result.Readings[1] != 2 || result.Threshold != nil {
+		t.Errorf("unexpected values: %+v", result)
+	}
+
+	// 整数形式的时间仍按 time 标签解析
+	body, _ = vmsgpack.Marshal(map[string]any{"reported": 1700000000, "interval": 0})
+	result, _, err = chttp.Valid[deviceReport](newDeviceRequest("application/x-msgpack", body))
+	if err != nil || result.Reported.Unix() != 1700000000 || result.Interval != 0 {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
+
+// TestMsgpackErrors 测试 MessagePack 的格式错误、类型错误与校验错误
+func TestMsgpackErrors(t *testing.T) {
+	body, _ := vmsgpack.Marshal(map[string]any{"battery": 150})
+	_, _, err := chttp.Valid[deviceReport](newDeviceRequest("application/msgpack", body))
+	var validationErr *chttp.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Name != "battery" {
+		t.Errorf("expected validation error for battery, got %v", err)
+	}
+
+	body, _ = vmsgpack.Marshal(map[string]any{"battery": "full"})
+	_, _, err = chttp.Valid[deviceReport](newDeviceRequest("application/msgpack", body))
+	var conversionErr *chttp.FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "battery" {
+		t.Errorf("expected *chttp.FieldConversionError for battery, got %T: %v", err, err)
+	}
+
+	for _, body := range [][]byte{{0xc1}, append(body, 0x00), body[:len(body)-1]} {
+		_, _, err = chttp.Valid[deviceReport](newDeviceRequest("application/msgpack", body))
+		var decodeErr *chttp.DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/msgpack" {
+			t.Errorf("%x: expected *chttp.DecodeError, got %T: %v", body, err, err)
+		}
+	}
+}
+
+// TestMsgpackLengths 测试头部声明的长度超过请求体时直接返回 DecodeError，不按声明的长度分配内存
+func TestMsgpackLengths(t *testing.T) {
+	deep := bytes.Repeat([]byte{0x91}, maxDepth+1)
+	for _, body := range [][]byte{
+		{0xdd, 0xff, 0xff, 0xff, 0xff},                  // array32
+		{0xdf, 0xff, 0xff, 0xff, 0xff},                  // map32
+		{0xc6, 0xff, 0xff, 0xff, 0xff},                  // bin32
+		{0xdb, 0xff, 0xff, 0xff, 0xff},                  // str32
+		{0xc9, 0xff, 0xff, 0xff, 0xff, 0xff},            // ext32
+		{0x81, 0xa1, 'a', 0xdd, 0x00, 0x01, 0x00, 0x00}, // 嵌套的 array32
+		append(deep, 0xc0),
+	} {
+		_, _, err := chttp.Valid[deviceReport](newDeviceRequest("application/msgpack", body))
+		var decodeErr *chttp.DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/msgpack" {
+			t.Errorf("%x: expected *DecodeError, got %T: %v", body, err, err)
+		}
+	}
+
+	// map 的元素个数恰好等于剩余字节数时仍可解码
+	body := []byte{0x81, 0xa7, 'b', 'a', 't', 't', 'e', 'r', 'y', 0x32}
+	result, _, err := chttp.Valid[deviceReport](newDeviceRequest("application/msgpack", body))
+	if err != nil || result.Battery != 50 {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/kuah/chttp/codec/protobuf
+
+go 1.22.10
+
+require (
+	github.com/go-chi/chi/v5 v5.2.0
+	github.com/kuah/chttp v0.1.0
+	github.com/pkg/errors v0.9.1
+	google.golang.org/protobuf v1.36.7
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/go-playground/validator/v10 v10.24.0 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	golang.org/x/crypto v0.32.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
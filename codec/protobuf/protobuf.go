@@ -0,0 +1,116 @@
+// Package protobuf 为 chttp 提供 protobuf 请求体解码器，导入本包即在默认 Binder 上注册，
+// 自行创建的 Binder 使用 Register 注册
+package protobuf
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/kuah/chttp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MediaType 二进制 protobuf 请求体的 media type，报告错误时使用
+const MediaType = "application/x-protobuf"
+
+// MediaTypes 注册二进制解码器的 media type
+var MediaTypes = []string{MediaType, "application/protobuf", "application/vnd.google.protobuf"}
+
+// JSONMediaTypes 注册 JSON 解码器的 media type，生成的消息类型按 protojson 的规则解码，其余类型交给原来的解码器
+var JSONMediaTypes = []string{"application/json", "application/*+json"}
+
+var messageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+func init() {
+	Register(chttp.DefaultBinder())
+}
+
+// Register 在 b 上为 MediaTypes 注册二进制解码器，并为 JSONMediaTypes 包装已经注册的 JSON 解码器，
+// 之后注册的 JSON 解码器会替换包装后的解码器
+func Register(b *chttp.Binder) {
+	d := NewDecoder()
+	for _, pattern := range MediaTypes {
+		b.RegisterBodyDecoder(pattern, d)
+	}
+	for _, pattern := range JSONMediaTypes {
+		b.RegisterBodyDecoder(pattern, NewJSONDecoder(b, b.LookupBodyDecoder(pattern)))
+	}
+}
+
+// NewDecoder 返回二进制 protobuf 解码器，只能解码到生成的消息类型（Bind[*pb.Order] 或 body 标签的字段）
+func NewDecoder() chttp.BodyDecoder {
+	return &decoder{}
+}
+
+type decoder struct{}
+
+// Decode 使用 proto.Unmarshal 解码请求体
+func (d *decoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	msg, ok := messageOf(v)
+	if !ok {
+		return &chttp.DecodeError{MediaType: MediaType, Err: errors.Errorf("cannot decode protobuf into %T, use a generated message type", v)}
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return &chttp.DecodeError{MediaType: MediaType, Err: err}
+	}
+	return nil
+}
+
+// NewJSONDecoder 返回使用 b 的配置解码的 JSON 解码器：生成的消息类型使用 protojson（驼峰字段名、枚举名称、
+// 字符串形式的 int64 等），未开启严格模式时忽略未知字段；其余类型交给 next，next 为 nil 时交给 b.DecodeJSON
+func NewJSONDecoder(b *chttp.Binder, next chttp.BodyDecoder) chttp.BodyDecoder {
+	return &jsonDecoder{binder: b, next: next}
+}
+
+type jsonDecoder struct {
+	binder *chttp.Binder
+	next   chttp.BodyDecoder
+}
+
+// BodyKeyTag 返回原来的 JSON 解码器声明的键名标签，没有声明时为 json
+func (d *jsonDecoder) BodyKeyTag() string {
+	if tagger, ok := d.next.(chttp.BodyKeyTagger); ok {
+		return tagger.BodyKeyTag()
+	}
+	return "json"
+}
+
+// Decode 按目标类型选择 protojson 或原来的 JSON 解码器
+func (d *jsonDecoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	msg, ok := messageOf(v)
+	if !ok {
+		if d.next != nil {
+			return d.next.Decode(r, v, present)
+		}
+		return d.binder.DecodeJSON(r, v, present, chttp.BodyFormat{MediaType: "application/json", KeyTag: "json"})
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: !d.binder.IsStrictJSON(v)}).Unmarshal(body, msg); err != nil {
+		return &chttp.DecodeError{MediaType: "application/json", Err: err}
+	}
+	return nil
+}
+
+// messageOf 返回 v 指向的 protobuf 消息，v 为 **pb.Order 时按需分配消息
+func messageOf(v any) (proto.Message, bool) {
+	if msg, ok := v.(proto.Message); ok {
+		return msg, true
+	}
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Ptr || !rv.Type().Implements(messageType) {
+		return nil, false
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.New(rv.Type().Elem()))
+	}
+	return rv.Interface().(proto.Message), true
+}
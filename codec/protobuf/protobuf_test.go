@@ -0,0 +1,155 @@
+package protobuf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kuah/chttp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+type registerAPIReq struct {
+	TraceId string     `header:"traceId" v:"required"`
+	Service string     `url:"service" v:"required"`
+	DryRun  bool       `param:"dryRun"`
+	API     *apipb.Api `body:"" v:"required"`
+}
+
+// newProtoRequest 构造带路径参数的 protobuf 请求
+func newProtoRequest(contentType string, body []byte) *http.Request {
+	req, _ := http.NewRequest("POST", "/services/orders/apis?dryRun=true", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("traceId", "t-1")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("service", "orders")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestProtobufBinding 测试二进制 protobuf 请求体绑定到 body 字段，其余字段来自 header、路径参数与 query
+func TestProtobufBinding(t *testing.T) {
+	body, err := proto.Marshal(&apipb.Api{
+		Name:    "orders.v1.Orders",
+		Version: "v1",
+		Methods: []*apipb.Method{{Name: "Get", RequestStreaming: true}},
+		Syntax:  typepb.Syntax_SYNTAX_PROTO3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, _, err := chttp.Valid[registerAPIReq](newProtoRequest("application/x-protobuf", body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TraceId != "t-1" || result.Service != "orders" || !result.DryRun {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.API.GetName() != "orders.v1.Orders" || len(result.API.GetMethods()) != 1 || !result.API.GetMethods()[0].GetRequestStreaming() || result.API.GetSyntax() != typepb.Syntax_SYNTAX_PROTO3 {
+		t.Errorf("unexpected message: %v", result.API)
+	}
+
+	// 顶层类型为消息指针
+	msg, _, err := chttp.Valid[*apipb.Api](newProtoRequest("application/protobuf", body))
+	if err != nil || msg.GetVersion() != "v1" {
+		t.Errorf("unexpected message: %v, %v", msg, err)
+	}
+
+	_, _, err = chttp.Valid[registerAPIReq](newProtoRequest("application/x-protobuf", []byte{0x0a, 0x05, 'a'}))
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/x-protobuf" {
+		t.Errorf("expected *chttp.DecodeError for truncated message, got %T: %v", err, err)
+	}
+
+	// 不是生成的消息类型时无法解码
+	type plain struct {
+		Name string `json:"name"`
+	}
+	_, _, err = chttp.Valid[plain](newProtoRequest("application/x-protobuf", body))
+	if !errors.As(err, &decodeErr) {
+		t.Errorf("expected *chttp.DecodeError for non-message type, got %T: %v", err, err)
+	}
+
+	// 请求体为空时 body 字段未通过 required 校验
+	_, _, err = chttp.Valid[registerAPIReq](newProtoRequest("application/x-protobuf", nil))
+	var validationErr *chttp.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Field != "API" {
+		t.Errorf("expected validation error for API, got %v", err)
+	}
+}
+
+// TestProtoJSONBinding 测试 JSON 请求体按 protojson 的规则解码到消息
+func TestProtoJSONBinding(t *testing.T) {
+	body := `{"name":"orders.v1.Orders","methods":[{"name":"Get","requestStreaming":true}],"syntax":"SYNTAX_PROTO3","unknown":1}`
+	result, _, err := chttp.Valid[registerAPIReq](newProtoRequest("application/json", []byte(body)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.API.GetName() != "orders.v1.Orders" || !result.API.GetMethods()[0].GetRequestStreaming() || result.API.GetSyntax() != typepb.Syntax_SYNTAX_PROTO3 {
+		t.Errorf("unexpected message: %v", result.API)
+	}
+
+	// 严格模式下不允许未知字段
+	strictBinder := chttp.NewBinder(chttp.WithStrictJSON())
+	Register(strictBinder)
+	_, _, err = chttp.Bind[registerAPIReq](strictBinder, newProtoRequest("application/json", []byte(body)))
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/json" {
+		t.Errorf("expected *chttp.DecodeError for unknown field, got %T: %v", err, err)
+	}
+
+	_, _, err = chttp.Valid[registerAPIReq](newProtoRequest("application/json", []byte(`{"name":1}`)))
+	if !errors.As(err, &decodeErr) {
+		t.Errorf("expected *chttp.DecodeError for mismatched type, got %T: %v", err, err)
+	}
+}
+
+// TestJSONDecoderPlainTypes 测试注册后普通类型的 JSON 请求体仍按 chttp 的规则解码，BindBody 使用注册的解码器
+func TestJSONDecoderPlainTypes(t *testing.T) {
+	type plain struct {
+		Name  string `json:"name" v:"required"`
+		Count int    `json:"count" default:"3"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	result, _, err := chttp.Valid[plain](req)
+	if err != nil || result.Name != "a" || result.Count != 3 {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"orders.v1.Orders","version":"v1"}`))
+	msg, err := chttp.ReadRequestBody[*apipb.Api](req)
+	if err != nil || (*msg).GetName() != "orders.v1.Orders" {
+		t.Errorf("unexpected message: %v, %v", msg, err)
+	}
+}
+
+// TestRegisterKeepsJSONDecoder 测试 Register 保留 Binder 上已经注册的 JSON 解码器，只接管生成的消息类型
+func TestRegisterKeepsJSONDecoder(t *testing.T) {
+	type plain struct {
+		Name string `json:"name"`
+	}
+	custom := chttp.BodyDecoderFunc(func(r io.Reader, v any, present map[string]bool) error {
+		v.(*plain).Name = "custom"
+		return nil
+	})
+	b := chttp.NewBinder(chttp.WithBodyDecoder("application/json", custom))
+	Register(b)
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if result, _, err := chttp.Bind[plain](b, req); err != nil || result.Name != "custom" {
+		t.Errorf("expected the custom decoder, got %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"orders.v1.Orders"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if msg, _, err := chttp.Bind[*apipb.Api](b, req); err != nil || msg.GetName() != "orders.v1.Orders" {
+		t.Errorf("unexpected message: %v, %v", msg, err)
+	}
+}
@@ -0,0 +1,67 @@
+// Package toml 为 chttp 提供 TOML 请求体解码器，导入本包即在默认 Binder 上注册，
+// 自行创建的 Binder 使用 Register 注册
+package toml
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/kuah/chttp"
+	gotoml "github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+)
+
+// MediaType TOML 请求体的 media type，报告错误时使用
+const MediaType = "application/toml"
+
+// MediaTypes 注册的 media type
+var MediaTypes = []string{MediaType, "application/x-toml"}
+
+func init() {
+	Register(chttp.DefaultBinder())
+}
+
+// Register 在 b 上为 MediaTypes 注册 TOML 解码器
+func Register(b *chttp.Binder) {
+	d := NewDecoder(b)
+	for _, pattern := range MediaTypes {
+		b.RegisterBodyDecoder(pattern, d)
+	}
+}
+
+// NewDecoder 返回使用 b 的配置解码的 TOML 解码器，键名使用 toml 标签，没有 toml 标签的字段使用 json 标签的名称
+func NewDecoder(b *chttp.Binder) chttp.BodyDecoder {
+	return &decoder{binder: b}
+}
+
+type decoder struct {
+	binder *chttp.Binder
+}
+
+// BodyKeyTag 请求体的键名使用 toml 标签，校验错误按该标签命名字段
+func (d *decoder) BodyKeyTag() string {
+	return "toml"
+}
+
+// Decode 将 TOML 转换为 JSON 后按 JSON 的规则解码，时间格式、出现过的字段与严格模式与 JSON 请求体一致
+func (d *decoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var doc map[string]any
+	if err := gotoml.Unmarshal(body, &doc); err != nil {
+		decodeErr := &chttp.DecodeError{MediaType: MediaType, Err: err}
+		var tomlErr *gotoml.DecodeError
+		if errors.As(err, &tomlErr) {
+			decodeErr.Line, decodeErr.Column = tomlErr.Position()
+		}
+		return decodeErr
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return &chttp.DecodeError{MediaType: MediaType, Err: err}
+	}
+	return d.binder.DecodeJSON(bytes.NewReader(data), v, present, chttp.BodyFormat{MediaType: MediaType, KeyTag: "toml"})
+}
@@ -0,0 +1,74 @@
+package toml
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kuah/chttp"
+)
+
+type pipelineStep struct {
+	Name    string        `json:"name" v:"required"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+type pipelineConfig struct {
+	TraceId  string            `header:"traceId" v:"required"`
+	Name     string            `json:"name" v:"required"`
+	Replicas int               `toml:"replicas" default:"2"`
+	Enabled  bool              `json:"enabled" default:"true"`
+	StartAt  time.Time         `json:"startAt"`
+	Labels   map[string]string `json:"labels"`
+	Steps    []pipelineStep    `json:"steps" v:"dive"`
+	Internal string            `json:"internal" toml:"-"`
+}
+
+// TestTOMLBinding 测试 TOML 请求体按 json/toml 标签绑定，并记录出现过的字段
+func TestTOMLBinding(t *testing.T) {
+	body := `
+name = "deploy"
+replicas = 0
+startAt = 2024-03-01T08:00:00Z
+internal = "ignored"
+
+[labels]
+team = "infra"
+
+[[steps]]
+name = "build"
+timeout = "90s"
+`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/toml")
+	req.Header.Set("traceId", "t-1")
+	result, _, err := chttp.Valid[pipelineConfig](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "deploy" || result.Replicas != 0 || !result.Enabled || result.Internal != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !result.StartAt.Equal(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)) || result.Labels["team"] != "infra" {
+		t.Errorf("unexpected StartAt/Labels: %v %v", result.StartAt, result.Labels)
+	}
+	if len(result.Steps) != 1 || result.Steps[0].Timeout != 90*time.Second {
+		t.Errorf("unexpected Steps: %+v", result.Steps)
+	}
+}
+
+// TestTOMLErrors 测试 TOML 语法错误返回行列号
+func TestTOMLErrors(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("name = \"deploy\"\nreplicas = = 1\n"))
+	req.Header.Set("Content-Type", "application/toml")
+	_, _, err := chttp.Valid[pipelineConfig](req)
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/toml" || decodeErr.Line != 2 || decodeErr.Column == 0 {
+		t.Fatalf("expected *DecodeError with position, got %T: %v", err, err)
+	}
+	if chttp.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", chttp.StatusCode(err))
+	}
+}
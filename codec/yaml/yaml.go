@@ -0,0 +1,160 @@
+// Package yaml 为 chttp 提供 YAML 请求体解码器，导入本包即在默认 Binder 上注册，
+// 自行创建的 Binder 使用 Register 注册
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	goyaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/kuah/chttp"
+	"github.com/pkg/errors"
+)
+
+// MediaType YAML 请求体的 media type，报告错误时使用
+const MediaType = "application/yaml"
+
+// MaxAliasExpansion 别名展开后最多能增加的内容（按标量的字节数估算），超过时返回 DecodeError，
+// 避免 "billion laughs" 式的请求体在转换为 JSON 时耗尽内存
+const MaxAliasExpansion = 1 << 20
+
+// MediaTypes 注册的 media type
+var MediaTypes = []string{MediaType, "application/x-yaml", "text/yaml", "application/*+yaml"}
+
+func init() {
+	Register(chttp.DefaultBinder())
+}
+
+// Register 在 b 上为 MediaTypes 注册 YAML 解码器
+func Register(b *chttp.Binder) {
+	d := NewDecoder(b)
+	for _, pattern := range MediaTypes {
+		b.RegisterBodyDecoder(pattern, d)
+	}
+}
+
+// NewDecoder 返回使用 b 的配置解码的 YAML 解码器，键名使用 yaml 标签，没有 yaml 标签的字段使用 json 标签的名称
+func NewDecoder(b *chttp.Binder) chttp.BodyDecoder {
+	return &decoder{binder: b}
+}
+
+type decoder struct {
+	binder *chttp.Binder
+}
+
+// BodyKeyTag 请求体的键名使用 yaml 标签，校验错误按该标签命名字段
+func (d *decoder) BodyKeyTag() string {
+	return "yaml"
+}
+
+// Decode 将 YAML 转换为 JSON 后按 JSON 的规则解码，时间格式、出现过的字段与严格模式与 JSON 请求体一致
+func (d *decoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	file, err := parser.ParseBytes(body, 0)
+	if err != nil {
+		return decodeError(err)
+	}
+	if err := checkAliases(file); err != nil {
+		return err
+	}
+	data, err := goyaml.YAMLToJSON(body)
+	if err != nil {
+		return decodeError(err)
+	}
+	return d.binder.DecodeJSON(bytes.NewReader(data), v, present, chttp.BodyFormat{MediaType: MediaType, KeyTag: "yaml"})
+}
+
+// decodeError 将 YAML 的错误转换为带行列号的 DecodeError
+func decodeError(err error) error {
+	decodeErr := &chttp.DecodeError{MediaType: MediaType, Err: err}
+	var yamlErr goyaml.Error
+	if errors.As(err, &yamlErr) {
+		decodeErr.Err = errors.New(yamlErr.GetMessage())
+		if tok := yamlErr.GetToken(); tok != nil && tok.Position != nil {
+			decodeErr.Line, decodeErr.Column = tok.Position.Line, tok.Position.Column
+		}
+	}
+	return decodeErr
+}
+
+// checkAliases 在转换为 JSON 之前估算别名展开后增加的内容，超过 MaxAliasExpansion 时返回 DecodeError
+func checkAliases(file *ast.File) error {
+	c := &aliasCounter{anchors: make(map[string]int64)}
+	for _, doc := range file.Docs {
+		c.size(doc)
+		if c.expanded > MaxAliasExpansion {
+			return &chttp.DecodeError{MediaType: MediaType, Err: fmt.Errorf("aliases expand to more than %d bytes", MaxAliasExpansion)}
+		}
+	}
+	return nil
+}
+
+// aliasCounter 按文档顺序遍历节点，记录每个锚点展开后的大小
+type aliasCounter struct {
+	anchors  map[string]int64 // 锚点名 -> 展开后的大小
+	expanded int64            // 别名展开增加的大小
+}
+
+// size 返回节点展开别名后的大小，结果不超过 MaxAliasExpansion+1，避免层层嵌套的别名溢出
+func (c *aliasCounter) size(node ast.Node) int64 {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return 0
+	}
+	switch n := node.(type) {
+	case *ast.AliasNode:
+		size := c.anchors[tokenValue(n.Value)]
+		c.expanded = addSize(c.expanded, size)
+		return size
+	case *ast.AnchorNode:
+		size := c.size(n.Value)
+		c.anchors[tokenValue(n.Name)] = size
+		return size
+	}
+	size := int64(len(tokenValue(node))) + 1
+	children := &childNodes{parent: node}
+	ast.Walk(children, node)
+	for _, child := range children.nodes {
+		size = addSize(size, c.size(child))
+	}
+	return size
+}
+
+// addSize 相加并截断到 MaxAliasExpansion+1
+func addSize(a, b int64) int64 {
+	if a+b > MaxAliasExpansion {
+		return MaxAliasExpansion + 1
+	}
+	return a + b
+}
+
+// tokenValue 返回节点对应 token 的文本
+func tokenValue(node ast.Node) string {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return ""
+	}
+	if tok := node.GetToken(); tok != nil {
+		return tok.Value
+	}
+	return ""
+}
+
+// childNodes 使用 ast.Walk 收集节点的直接子节点
+type childNodes struct {
+	parent ast.Node
+	nodes  []ast.Node
+}
+
+func (c *childNodes) Visit(node ast.Node) ast.Visitor {
+	if node == c.parent {
+		return c
+	}
+	c.nodes = append(c.nodes, node)
+	return nil
+}
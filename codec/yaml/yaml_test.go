@@ -0,0 +1,171 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kuah/chttp"
+)
+
+type pipelineStep struct {
+	Name    string        `json:"name" v:"required"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+type pipelineConfig struct {
+	TraceId  string            `header:"traceId" v:"required"`
+	Name     string            `json:"name" v:"required"`
+	Replicas int               `yaml:"replicas" default:"2"`
+	Enabled  bool              `json:"enabled" default:"true"`
+	StartAt  time.Time         `json:"startAt"`
+	Labels   map[string]string `json:"labels"`
+	Steps    []pipelineStep    `json:"steps" v:"dive"`
+	Internal string            `json:"internal" yaml:"-"`
+}
+
+// TestYAMLBinding 测试 YAML 请求体按 json/yaml 标签绑定，并记录出现过的字段
+func TestYAMLBinding(t *testing.T) {
+	body := `
+name: deploy
+replicas: 0
+enabled: false
+startAt: 2024-03-01 08:00:00
+labels: &labels
+  team: infra
+internal: ignored
+steps:
+  - name: build
+    timeout: 90s
+  - name: test
+`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/yaml")
+	req.Header.Set("traceId", "t-1")
+	result, _, err := chttp.Valid[pipelineConfig](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 请求体中显式传入的零值不会被 default 覆盖
+	if result.Name != "deploy" || result.Replicas != 0 || result.Enabled || result.Internal != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.StartAt.Hour() != 8 || result.Labels["team"] != "infra" {
+		t.Errorf("unexpected StartAt/Labels: %v %v", result.StartAt, result.Labels)
+	}
+	if len(result.Steps) != 2 || result.Steps[0].Timeout != 90*time.Second {
+		t.Errorf("unexpected Steps: %+v", result.Steps)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString("name: deploy\nsteps:\n  - timeout: 1s\n"))
+	req.Header.Set("Content-Type", "text/yaml")
+	req.Header.Set("traceId", "t-1")
+	result, _, err = chttp.Valid[pipelineConfig](req)
+	var validationErr *chttp.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Name != "steps[0].name" {
+		t.Errorf("expected validation error for steps[0].name, got %v", err)
+	}
+	if result.Replicas != 2 || !result.Enabled {
+		t.Errorf("expected defaults for missing keys, got %+v", result)
+	}
+}
+
+// TestYAMLRegister 测试在自行创建的 Binder 上注册
+func TestYAMLRegister(t *testing.T) {
+	b := chttp.NewBinder()
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("name: deploy\n"))
+	req.Header.Set("Content-Type", "application/x-yaml")
+	var unsupported *chttp.UnsupportedMediaTypeError
+	if _, _, err := chttp.Bind[pipelineConfig](b, req); !errors.As(err, &unsupported) {
+		t.Fatalf("expected *UnsupportedMediaTypeError before Register, got %T: %v", err, err)
+	}
+
+	Register(b)
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString("name: deploy\n"))
+	req.Header.Set("Content-Type", "application/x-yaml")
+	req.Header.Set("traceId", "t-1")
+	if result, _, err := chttp.Bind[pipelineConfig](b, req); err != nil || result.Name != "deploy" {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
+
+// TestYAMLErrors 测试 YAML 语法错误返回行列号，类型错误返回转换错误
+func TestYAMLErrors(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("name: deploy\nsteps:\n  - name: [build\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	_, _, err := chttp.Valid[pipelineConfig](req)
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/yaml" || decodeErr.Line != 3 || decodeErr.Column == 0 {
+		t.Fatalf("expected *DecodeError with position, got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString("replicas: many\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	_, _, err = chttp.Valid[pipelineConfig](req)
+	var conversionErr *chttp.FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "replicas" || conversionErr.Field != "Replicas" {
+		t.Errorf("expected *FieldConversionError for replicas, got %T: %v", err, err)
+	}
+}
+
+// TestYAMLAliasExpansion 测试别名展开过大的请求体被拒绝，普通的别名与合并键仍然可用
+func TestYAMLAliasExpansion(t *testing.T) {
+	body := `
+a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/yaml")
+	_, _, err := chttp.Valid[pipelineConfig](req)
+	var decodeErr *chttp.DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/yaml" {
+		t.Fatalf("expected *DecodeError for expanding aliases, got %T: %v", err, err)
+	}
+
+	body = `
+defaults: &defaults
+  timeout: 30s
+name: deploy
+labels: &labels
+  team: infra
+steps:
+  - <<: *defaults
+    name: build
+  - <<: *defaults
+    name: test
+`
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/yaml")
+	req.Header.Set("traceId", "t-1")
+	result, _, err := chttp.Valid[pipelineConfig](req)
+	if err != nil || len(result.Steps) != 2 || result.Steps[1].Name != "test" || result.Steps[1].Timeout != 30*time.Second {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
+
+// TestYAMLValidationNames 测试校验错误按 yaml 标签命名字段，没有 yaml 标签时使用 json 标签的名称
+func TestYAMLValidationNames(t *testing.T) {
+	type rollout struct {
+		MaxSurge int            `json:"maxSurge" yaml:"max_surge" v:"required"`
+		Steps    []pipelineStep `json:"steps" v:"dive"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString("steps:\n  - timeout: 1s\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	_, _, err := chttp.Valid[rollout](req)
+	var validationErr *chttp.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("expected 2 validation errors, got %v", err)
+	}
+	if validationErr.Errors[0].Name != "max_surge" || validationErr.Errors[1].Name != "steps[0].name" {
+		t.Errorf("unexpected names: %+v", validationErr.Errors)
+	}
+}
@@ -0,0 +1,144 @@
+package chttp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ContextExtractor 从请求上下文中取出 ctx 标签对应的值，ok 为 false 表示不存在
+type ContextExtractor func(ctx context.Context) (value interface{}, ok bool)
+
+// WithContextKey 将 ctx 标签中的 name 映射到 context key，即绑定 ctx.Value(key)
+func WithContextKey(name string, key interface{}) Option {
+	return WithContextExtractor(name, contextKeyExtractor(key))
+}
+
+// WithContextExtractor 使用 fn 取出 ctx 标签中 name 对应的值
+func WithContextExtractor(name string, fn ContextExtractor) Option {
+	return func(b *Binder) {
+		b.contextValues[name] = fn
+	}
+}
+
+// RegisterContextKey 在 Binder 上注册 ctx 标签名与 context key 的映射，应在开始处理请求之前完成
+func (b *Binder) RegisterContextKey(name string, key interface{}) {
+	b.contextValues[name] = contextKeyExtractor(key)
+}
+
+// RegisterContextExtractor 在 Binder 上注册 ctx 标签名对应的取值函数，应在开始处理请求之前完成
+func (b *Binder) RegisterContextExtractor(name string, fn ContextExtractor) {
+	b.contextValues[name] = fn
+}
+
+// RegisterContextKey 在默认 Binder 上注册 ctx 标签名与 context key 的映射
+func RegisterContextKey(name string, key interface{}) {
+	defaultBinder.RegisterContextKey(name, key)
+}
+
+// RegisterContextExtractor 在默认 Binder 上注册 ctx 标签名对应的取值函数
+func RegisterContextExtractor(name string, fn ContextExtractor) {
+	defaultBinder.RegisterContextExtractor(name, fn)
+}
+
+func contextKeyExtractor(key interface{}) ContextExtractor {
+	return func(ctx context.Context) (interface{}, bool) {
+		value := ctx.Value(key)
+		return value, value != nil
+	}
+}
+
+// contextValue 按 ctx 标签名从请求上下文取值，未注册的名称视为不存在
+func (b *Binder) contextValue(ctx context.Context, name string) (interface{}, bool) {
+	extract, ok := b.contextValues[name]
+	if !ok {
+		return nil, false
+	}
+	value, ok := extract(ctx)
+	return value, ok && value != nil
+}
+
+// setContextField 将上下文中的值写入字段：类型可直接赋值时直接赋值，否则按字符串转换
+func (f *fieldPlan) setContextField(field reflect.Value, value interface{}) error {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(f.typ):
+		field.Set(rv)
+		return nil
+	case f.typ.Kind() == reflect.Ptr && rv.Type().AssignableTo(f.typ.Elem()):
+		ptr := reflect.New(f.typ.Elem())
+		ptr.Elem().Set(rv)
+		field.Set(ptr)
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		return f.set(field, s)
+	}
+	return f.set(field, fmt.Sprint(value))
+}
+
+// clearContextFields 清除 v 中任何层级（嵌套结构体、指针、切片、数组与 map 元素）带 ctx 标签的字段，
+// 请求体解码器（包括不使用字段索引的 encoding/xml 与自定义解码器）写入的值都会被丢弃
+func (b *Binder) clearContextFields(v reflect.Value) {
+	if !b.hasContextFields(v.Type()) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			b.clearContextFields(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			b.clearContextFields(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// map 元素不可寻址，复制后清除再写回
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			b.clearContextFields(elem)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Struct:
+		for _, f := range b.planFor(v.Type()).fields {
+			field := v.Field(f.index)
+			switch {
+			case f.ctx != "" && field.CanSet():
+				field.Set(reflect.Zero(f.typ))
+			case f.settable || f.anonymous:
+				// 未导出的嵌入结构体中导出的字段仍然可以被解码器写入
+				b.clearContextFields(field)
+			}
+		}
+	}
+}
+
+// hasContextFields 类型 t 的任何层级是否带 ctx 标签的字段，结果按类型缓存
+func (b *Binder) hasContextFields(t reflect.Type) bool {
+	if has, ok := b.ctxTypes.Load(t); ok {
+		return has.(bool)
+	}
+	has := b.scanContextFields(t, make(map[reflect.Type]bool))
+	b.ctxTypes.Store(t, has)
+	return has
+}
+
+// scanContextFields 递归检查类型中是否有带 ctx 标签的字段，visiting 避免自引用类型无限递归
+func (b *Binder) scanContextFields(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Tag.Get(b.tags.Context) != "" || b.scanContextFields(sf.Type, visiting) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package chttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type ctxKey string
+
+type authClaims struct {
+	Subject string
+	Roles   []string
+}
+
+// TestContextBinding 测试 ctx 标签从请求上下文绑定值
+func TestContextBinding(t *testing.T) {
+	type testStruct struct {
+		UserId   int64       `ctx:"userId" v:"required"`
+		TenantId *string     `ctx:"tenant" v:"required"`
+		TraceId  string      `ctx:"traceId"`
+		Claims   *authClaims `ctx:"claims"`
+		Region   string      `ctx:"region" default:"cn"`
+		Name     string      `json:"name"`
+	}
+
+	b := NewBinder(
+		WithContextKey("userId", ctxKey("user")),
+		WithContextKey("tenant", ctxKey("tenant")),
+		WithContextExtractor("traceId", func(ctx context.Context) (interface{}, bool) {
+			id, ok := ctx.Value(ctxKey("trace")).(string)
+			return "trace-" + id, ok
+		}),
+	)
+	b.RegisterContextKey("claims", ctxKey("claims"))
+	b.RegisterContextKey("region", ctxKey("region"))
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "42")
+	ctx = context.WithValue(ctx, ctxKey("tenant"), "t-1")
+	ctx = context.WithValue(ctx, ctxKey("trace"), "abc")
+	ctx = context.WithValue(ctx, ctxKey("claims"), &authClaims{Subject: "u-42", Roles: []string{"admin"}})
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", "/test", bytes.NewBufferString(`{"name":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	result, parserResult, err := Bind[testStruct](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	if result.UserId != 42 || result.TenantId == nil || *result.TenantId != "t-1" || result.TraceId != "trace-abc" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Claims == nil || result.Claims.Subject != "u-42" {
+		t.Errorf("unexpected claims: %+v", result.Claims)
+	}
+	if result.Region != "cn" || result.Name != "a" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// TestContextCannotBeOverridden 测试客户端无法通过请求体、header 或 query 覆盖上下文中的值
+func TestContextCannotBeOverridden(t *testing.T) {
+	type testStruct struct {
+		UserId string `ctx:"userId" json:"userId" header:"X-User" param:"userId" v:"required"`
+	}
+	b := NewBinder(WithContextKey("userId", ctxKey("user")))
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "u-1")
+	req, _ := http.NewRequestWithContext(ctx, "POST", "/test?userId=evil", bytes.NewBufferString(`{"userId":"evil"}`))
+	req.Header.Set("X-User", "evil")
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil || result.UserId != "u-1" {
+		t.Errorf("expected UserId from context, got %q, %v", result.UserId, err)
+	}
+
+	// 上下文中没有值时，客户端的值同样被忽略
+	req, _ = http.NewRequest("POST", "/test?userId=evil", bytes.NewBufferString(`{"userId":"evil"}`))
+	req.Header.Set("X-User", "evil")
+	result, parserResult, err := Bind[testStruct](b, req)
+	if parserResult != ParserResultNotVerified || result.UserId != "" {
+		t.Fatalf("expected ParserResultNotVerified with empty UserId, got %v %q", parserResult, result.UserId)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != "context userId is required" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestContextConversionError 测试上下文中的值无法转换时返回的错误
+func TestContextConversionError(t *testing.T) {
+	type testStruct struct {
+		UserId int `ctx:"userId"`
+	}
+	RegisterContextKey("userId", ctxKey("user"))
+	defer delete(defaultBinder.contextValues, "userId")
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "not-a-number")
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/test", nil)
+	_, _, err := Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Source != SourceContext || conversionErr.Value != "not-a-number" {
+		t.Errorf("expected context *FieldConversionError, got %T: %v", err, err)
+	}
+}
+
+type ctxMeta struct {
+	UserId string `json:"userId" xml:"userId" ctx:"uid"`
+	Name   string `json:"name" xml:"name"`
+}
+
+// TestNestedContextFieldsIgnoreBody 测试嵌套结构体、切片元素与 body 字段中带 ctx 标签的字段不会被请求体写入
+func TestNestedContextFieldsIgnoreBody(t *testing.T) {
+	type nestedStruct struct {
+		Meta  ctxMeta   `json:"meta" xml:"meta"`
+		Items []ctxMeta `json:"items" xml:"items"`
+	}
+	type bodyStruct struct {
+		Meta ctxMeta `body:""`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"meta":{"userId":"evil","name":"a"},"items":[{"userId":"evil","name":"b"}]}`))
+	result, _, err := Valid[nestedStruct](req)
+	if err != nil || result.Meta.UserId != "" || result.Meta.Name != "a" || len(result.Items) != 1 || result.Items[0].UserId != "" || result.Items[0].Name != "b" {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`<req><meta><userId>evil</userId><name>a</name></meta><items><userId>evil</userId></items></req>`))
+	req.Header.Set("Content-Type", "application/xml")
+	result, _, err = Valid[nestedStruct](req)
+	if err != nil || result.Meta.UserId != "" || result.Meta.Name != "a" || len(result.Items) != 1 || result.Items[0].UserId != "" {
+		t.Errorf("unexpected XML result: %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"userId":"evil","name":"c"}`))
+	bodyResult, _, err := Valid[bodyStruct](req)
+	if err != nil || bodyResult.Meta.UserId != "" || bodyResult.Meta.Name != "c" {
+		t.Errorf("unexpected body result: %+v, %v", bodyResult, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"userId":"evil","name":"d"}`))
+	meta, err := ReadRequestBody[ctxMeta](req)
+	if err != nil || meta.UserId != "" || meta.Name != "d" {
+		t.Errorf("unexpected ReadRequestBody result: %+v, %v", meta, err)
+	}
+}
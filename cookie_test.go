@@ -0,0 +1,97 @@
+package chttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestCookieBinding 测试 cookie 标签绑定、类型转换与默认值
+func TestCookieBinding(t *testing.T) {
+	type testStruct struct {
+		SessionId string       `cookie:"sid" v:"required"`
+		Bucket    *int         `cookie:"ab_bucket"`
+		Theme     string       `cookie:"theme" default:"light"`
+		Consent   bool         `cookie:"consent" default:"true"`
+		Session   *http.Cookie `cookie:"sid"`
+		Locale    http.Cookie  `cookie:"locale"`
+		Tracking  *http.Cookie `cookie:"tracking"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.AddCookie(&http.Cookie{Name: "sid", Value: "s-123"})
+	req.AddCookie(&http.Cookie{Name: "ab_bucket", Value: "7"})
+	req.AddCookie(&http.Cookie{Name: "consent", Value: "false"})
+	req.AddCookie(&http.Cookie{Name: "locale", Value: "zh-CN"})
+
+	result, parserResult, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	if result.SessionId != "s-123" || result.Bucket == nil || *result.Bucket != 7 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Theme != "light" || result.Consent != false {
+		t.Errorf("expected default for missing cookie only, got Theme=%q Consent=%v", result.Theme, result.Consent)
+	}
+	if result.Session == nil || result.Session.Name != "sid" || result.Session.Value != "s-123" {
+		t.Errorf("unexpected Session cookie: %+v", result.Session)
+	}
+	if result.Locale.Value != "zh-CN" {
+		t.Errorf("unexpected Locale cookie: %+v", result.Locale)
+	}
+	if result.Tracking != nil {
+		t.Errorf("expected Tracking to be nil, got %+v", result.Tracking)
+	}
+}
+
+// TestCookiePriority 测试 cookie 的优先级：高于 query，低于 header 与请求体
+func TestCookiePriority(t *testing.T) {
+	type testStruct struct {
+		Lang string `json:"lang" param:"lang" cookie:"lang" header:"X-Lang"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?lang=query", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "cookie"})
+	if result, _, _ := Valid[testStruct](req); result.Lang != "cookie" {
+		t.Errorf("expected cookie to override query, got %q", result.Lang)
+	}
+
+	req.Header.Set("X-Lang", "header")
+	if result, _, _ := Valid[testStruct](req); result.Lang != "header" {
+		t.Errorf("expected header to override cookie, got %q", result.Lang)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"lang":"body"}`))
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "cookie"})
+	if result, _, _ := Valid[testStruct](req); result.Lang != "body" {
+		t.Errorf("expected body to override cookie, got %q", result.Lang)
+	}
+}
+
+// TestCookieErrors 测试 cookie 的转换与校验错误
+func TestCookieErrors(t *testing.T) {
+	type testStruct struct {
+		Bucket    int    `cookie:"ab_bucket"`
+		SessionId string `cookie:"sid" v:"required"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.AddCookie(&http.Cookie{Name: "ab_bucket", Value: "x"})
+	_, _, err := Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Source != SourceCookie || conversionErr.Name != "ab_bucket" {
+		t.Fatalf("expected cookie *FieldConversionError, got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("GET", "/test", nil)
+	_, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != "cookie sid is required" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
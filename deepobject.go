@@ -0,0 +1,100 @@
+package chttp
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// deepObjectValues 将 "name.a.b" 形式的 query 键统一转换为 "name[a][b]"，其余键保持不变
+func deepObjectValues(values url.Values, name string) url.Values {
+	dotted := false
+	for key := range values {
+		if strings.HasPrefix(key, name+".") {
+			dotted = true
+			break
+		}
+	}
+	if !dotted {
+		return values
+	}
+	result := make(url.Values, len(values))
+	for key, vs := range values {
+		if rest, ok := strings.CutPrefix(key, name+"."); ok {
+			key = name + "[" + strings.ReplaceAll(rest, ".", "][") + "]"
+		}
+		result[key] = append(result[key], vs...)
+	}
+	return result
+}
+
+// hasDeepKeys 是否存在以 "name[" 开头的 query 键
+func hasDeepKeys(values url.Values, name string) bool {
+	for key := range values {
+		if strings.HasPrefix(key, name+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// maxDeepObjectDepth deepObject 参数的最大嵌套层数，更深的参数（如自引用类型的 a[next][next]...）被忽略
+const maxDeepObjectDepth = 32
+
+// bindDeepObject 将 deepObject 风格的 query 参数绑定到 map 或嵌套结构体，path 为字段路径，
+// 嵌套结构体的字段按自身标签继续解析，默认值等规则与顶层字段一致
+func (b *Binder) bindDeepObject(r *http.Request, values url.Values, field reflect.Value, f *fieldPlan, path string, explicitlySetFields map[string]Source) error {
+	if strings.Count(f.query, "[") >= maxDeepObjectDepth {
+		return nil
+	}
+	values = deepObjectValues(values, f.query)
+	if f.typ.Kind() == reflect.Map {
+		return b.bindDeepMap(values, field, f, path, explicitlySetFields)
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			// 没有任何对应的参数时保持 nil
+			if !hasDeepKeys(values, f.query) {
+				return nil
+			}
+			field.Set(reflect.New(f.typ.Elem()))
+		}
+		field = field.Elem()
+	}
+	return b.parseRequestParamsWithPlan(r, values, field, f.nested(), path+".", explicitlySetFields)
+}
+
+// bindDeepMap 将 name[key]=value 形式的 query 参数写入 map[string]T 字段，
+// 请求体已经设置该字段且 query 优先级更低时不覆盖
+func (b *Binder) bindDeepMap(values url.Values, field reflect.Value, f *fieldPlan, path string, explicitlySetFields map[string]Source) error {
+	if _, set := explicitlySetFields[path]; set && b.sourceRank(SourceQuery) < b.sourceRank(SourceBody) {
+		return nil
+	}
+	var result reflect.Value
+	for key, vs := range values {
+		rest, ok := strings.CutPrefix(key, f.query+"[")
+		if !ok || !strings.HasSuffix(rest, "]") || len(vs) == 0 {
+			continue
+		}
+		mapKey := strings.TrimSuffix(rest, "]")
+		if mapKey == "" || strings.ContainsAny(mapKey, "[]") {
+			continue
+		}
+		elem := reflect.New(f.typ.Elem()).Elem()
+		if err := f.setElem(elem, vs); err != nil {
+			conversionErr := f.conversionError(path, SourceQuery, strings.Join(vs, ","), err)
+			conversionErr.Name = key
+			return conversionErr
+		}
+		if !result.IsValid() {
+			result = reflect.MakeMap(f.typ)
+		}
+		result.SetMapIndex(reflect.ValueOf(mapKey).Convert(f.typ.Key()), elem)
+	}
+	if result.IsValid() {
+		field.Set(result)
+		explicitlySetFields[path] = SourceQuery
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package chttp
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestDeepObjectMapBinding 测试 filter[status]=open 形式的 query 参数绑定到 map
+func TestDeepObjectMapBinding(t *testing.T) {
+	type testStruct struct {
+		Filter map[string]string   `param:"filter"`
+		Sort   map[string]string   `param:"sort"`
+		Tags   map[string][]string `param:"tags"`
+		Limits map[string]int      `param:"limits"`
+		Empty  map[string]string   `param:"empty"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?filter[status]=open&filter[owner]=me&sort.created=desc&tags[env]=prod&tags[env]=dev&limits[page]=20", nil)
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Filter, map[string]string{"status": "open", "owner": "me"}) {
+		t.Errorf("unexpected Filter: %v", result.Filter)
+	}
+	if !reflect.DeepEqual(result.Sort, map[string]string{"created": "desc"}) {
+		t.Errorf("unexpected Sort: %v", result.Sort)
+	}
+	if !reflect.DeepEqual(result.Tags, map[string][]string{"env": {"prod", "dev"}}) {
+		t.Errorf("unexpected Tags: %v", result.Tags)
+	}
+	if result.Limits["page"] != 20 {
+		t.Errorf("unexpected Limits: %v", result.Limits)
+	}
+	if result.Empty != nil {
+		t.Errorf("expected Empty to be nil, got %v", result.Empty)
+	}
+}
+
+// TestDeepObjectStructBinding 测试 deepObject 风格的 query 参数绑定到嵌套结构体
+func TestDeepObjectStructBinding(t *testing.T) {
+	type Owner struct {
+		Name string `param:"name"`
+	}
+	type Filter struct {
+		Status string   `param:"status" v:"required"`
+		Limit  int      `param:"limit" default:"10"`
+		Ids    []int    `param:"id"`
+		Owner  *Owner   `param:"owner"`
+		Labels []string `param:"labels,explode=false"`
+	}
+	type testStruct struct {
+		Filter   Filter  `json:"filter" param:"filter"`
+		Optional *Filter `param:"optional"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?filter[status]=open&filter[id]=1&filter[id]=2&filter.owner.name=alice&filter[labels]=a,b", nil)
+	result, parserResult, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	f := result.Filter
+	if f.Status != "open" || f.Limit != 10 || !reflect.DeepEqual(f.Ids, []int{1, 2}) || !reflect.DeepEqual(f.Labels, []string{"a", "b"}) {
+		t.Errorf("unexpected Filter: %+v", f)
+	}
+	if f.Owner == nil || f.Owner.Name != "alice" {
+		t.Errorf("unexpected Owner: %+v", f.Owner)
+	}
+	if result.Optional != nil {
+		t.Errorf("expected Optional to be nil, got %+v", result.Optional)
+	}
+
+	req, _ = http.NewRequest("GET", "/test?filter[limit]=5", nil)
+	_, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != "query filter[status] is required" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestDeepObjectErrors 测试 deepObject 参数的转换错误
+func TestDeepObjectErrors(t *testing.T) {
+	type Page struct {
+		Size int `param:"size"`
+	}
+	type testStruct struct {
+		Limits map[string]int `param:"limits"`
+		Page   Page           `param:"page"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?limits[page]=x", nil)
+	_, _, err := Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "limits[page]" || conversionErr.Source != SourceQuery {
+		t.Fatalf("expected *FieldConversionError for limits[page], got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("GET", "/test?page[size]=big", nil)
+	_, _, err = Valid[testStruct](req)
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "page[size]" || conversionErr.Field != "Page.Size" {
+		t.Fatalf("expected *FieldConversionError for page[size], got %T: %v", err, err)
+	}
+}
+
+type deepFilter struct {
+	Name string      `param:"name"`
+	Next *deepFilter `param:"next"`
+}
+
+// TestDeepObjectMaxDepth 测试超过最大层数的 deepObject 参数被忽略
+func TestDeepObjectMaxDepth(t *testing.T) {
+	type testStruct struct {
+		Filter *deepFilter `param:"filter"`
+	}
+
+	query := "filter" + strings.Repeat("[next]", maxDeepObjectDepth) + "[name]=x&filter[name]=a"
+	req, _ := http.NewRequest("GET", "/test?"+query, nil)
+	result, _, err := Valid[testStruct](req)
+	if err != nil || result.Filter == nil || result.Filter.Name != "a" {
+		t.Fatalf("unexpected result: %+v, %v", result.Filter, err)
+	}
+	depth := 0
+	for f := result.Filter; f.Next != nil; f = f.Next {
+		depth++
+	}
+	if depth >= maxDeepObjectDepth {
+		t.Errorf("expected nesting below %d, got %d", maxDeepObjectDepth, depth)
+	}
+}
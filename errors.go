@@ -0,0 +1,310 @@
+package chttp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// FieldError 单个字段未通过校验的详细信息
+type FieldError struct {
+	Field  string      // Go 字段路径，如 "BaseReq.TraceId"、"Items[0].Name"
+	Name   string      // 客户端实际使用的参数名，如 header 的 "traceId"、请求体中的 "items[0].name"
+	Source Source      // 参数来源
+	Rule   string      // 未通过的校验规则，如 "required"
+	Param  string      // 校验规则的参数，如 "max=10" 中的 "10"
+	Value  interface{} // 未通过校验的值
+}
+
+// Error 以客户端使用的参数名和位置描述错误，如 "header traceId is required"
+func (e FieldError) Error() string {
+	if e.Name == "" {
+		// 整个请求体未通过校验，如 body 标签的字段
+		return fmt.Sprintf("%s %s", e.Source, ruleMessage(e.Rule, e.Param))
+	}
+	return fmt.Sprintf("%s %s %s", e.Source, e.Name, ruleMessage(e.Rule, e.Param))
+}
+
+// ruleMessage 返回校验规则对应的描述
+func ruleMessage(rule, param string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "len":
+		return fmt.Sprintf("must have length %s", param)
+	case "min":
+		return fmt.Sprintf("must be at least %s", param)
+	case "max":
+		return fmt.Sprintf("must be at most %s", param)
+	case "eq":
+		return fmt.Sprintf("must be equal to %s", param)
+	case "ne":
+		return fmt.Sprintf("must not be equal to %s", param)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", param)
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", param)
+	case "lt":
+		return fmt.Sprintf("must be less than %s", param)
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", param)
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", param)
+	case "filesize":
+		return fmt.Sprintf("must not exceed %s", param)
+	case "filetype":
+		return fmt.Sprintf("must be a file of type [%s]", param)
+	case "email", "url", "uri", "uuid", "ip", "ipv4", "ipv6", "number", "numeric", "alpha", "alphanum", "e164":
+		return fmt.Sprintf("must be a valid %s", rule)
+	}
+	if param != "" {
+		return fmt.Sprintf("failed on the '%s=%s' rule", rule, param)
+	}
+	return fmt.Sprintf("failed on the '%s' rule", rule)
+}
+
+// ValidationError 请求参数校验失败，包含所有未通过校验的字段
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		messages = append(messages, fe.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// newValidationError 将 validator 的错误转换为 ValidationError，
+// explicitlySetFields 记录了每个字段实际使用的来源，keyTag 为请求体键名使用的标签，见 BodyKeyTagger
+func (b *Binder) newValidationError(t reflect.Type, errs validator.ValidationErrors, explicitlySetFields map[string]Source, keyTag string) *ValidationError {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return b.newElemValidationError(t, errs, keyTag)
+	}
+	plan := b.planFor(t)
+	result := &ValidationError{Errors: make([]FieldError, 0, len(errs))}
+	for _, fe := range errs {
+		// StructNamespace 形如 "TranferStoreReq.BaseReq.TraceId"，去掉最外层的类型名
+		path := fe.StructNamespace()
+		if i := strings.IndexByte(path, '.'); i >= 0 {
+			path = path[i+1:]
+		}
+		fieldErr := FieldError{
+			Field:  path,
+			Name:   fe.Field(),
+			Source: SourceBody,
+			Rule:   fe.Tag(),
+			Param:  fe.Param(),
+			Value:  fe.Value(),
+		}
+		if f, fieldPath, bodyPath := plan.resolve(path, keyTag); f != nil {
+			fieldErr.Source, fieldErr.Name = b.fieldSource(f, fieldPath, bodyPath, keyTag, explicitlySetFields)
+		}
+		result.Errors = append(result.Errors, fieldErr)
+	}
+	return result
+}
+
+// newElemValidationError 将顶层为切片、数组或 map 的请求体的校验错误转换为 ValidationError，
+// 校验器给出的路径形如 "[1].Items[0].Name"，对应请求体中的 "[1].items[0].name"
+func (b *Binder) newElemValidationError(t reflect.Type, errs validator.ValidationErrors, keyTag string) *ValidationError {
+	elem := t
+	for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array || elem.Kind() == reflect.Map {
+		elem = elem.Elem()
+	}
+	plan := b.planFor(elem)
+	result := &ValidationError{Errors: make([]FieldError, 0, len(errs))}
+	for _, fe := range errs {
+		path := fe.StructNamespace()
+		fieldErr := FieldError{
+			Field:  path,
+			Name:   path,
+			Source: SourceBody,
+			Rule:   fe.Tag(),
+			Param:  fe.Param(),
+			Value:  fe.Value(),
+		}
+		if index, rest, ok := strings.Cut(path, "."); ok {
+			if _, _, bodyPath := plan.resolve(rest, keyTag); bodyPath != "" {
+				fieldErr.Name = index + "." + bodyPath
+			}
+		}
+		result.Errors = append(result.Errors, fieldErr)
+	}
+	return result
+}
+
+// fieldSource 返回路径为 path 的字段实际绑定的来源及参数名；字段没有被任何来源设置时，
+// 使用字段声明的来源中优先级最高的一个
+func (b *Binder) fieldSource(f *fieldPlan, path, bodyPath, keyTag string, explicitlySetFields map[string]Source) (Source, string) {
+	if f.ctx != "" {
+		return SourceContext, f.ctx
+	}
+	if source, ok := explicitlySetFields[path]; ok {
+		if name := f.sourceName(source, bodyPath, keyTag); name != "" {
+			return source, name
+		}
+	}
+	for i := len(b.priority) - 1; i >= 0; i-- {
+		if name := f.sourceName(b.priority[i], bodyPath, keyTag); name != "" {
+			return b.priority[i], name
+		}
+	}
+	return SourceBody, bodyPath
+}
+
+// sourceName 返回字段在指定来源中的参数名，未声明该来源时返回空字符串，请求体按 keyTag 标签判断是否声明
+func (f *fieldPlan) sourceName(source Source, bodyPath, keyTag string) string {
+	switch source {
+	case SourceQuery:
+		return f.query
+	case SourceForm:
+		return f.form
+	case SourceCookie:
+		return f.cookie
+	case SourceContext:
+		return f.ctx
+	case SourceHeader:
+		return f.header
+	case SourcePath:
+		return f.url
+	case SourceBody:
+		if f.body || f.hasBodyKey(keyTag) {
+			return bodyPath
+		}
+	}
+	return ""
+}
+
+// DecodeError 请求体无法按其 Content-Type 解码
+type DecodeError struct {
+	MediaType string
+	Line      int // 语法错误所在的行与列，从 1 开始，0 表示未知
+	Column    int
+	Err       error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("body is not valid %s: line %d, column %d: %v", e.MediaType, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("body is not valid %s: %v", e.MediaType, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// FieldConversionError 参数的原始值无法转换为字段类型
+type FieldConversionError struct {
+	Field  string       // Go 字段路径
+	Name   string       // 客户端使用的参数名
+	Source Source       // 参数来源
+	Value  string       // 原始值
+	Type   reflect.Type // 字段类型
+	Err    error
+}
+
+func (e *FieldConversionError) Error() string {
+	return fmt.Sprintf("%s %s: cannot convert %q to %v: %v", e.Source, e.Name, e.Value, e.Type, e.Err)
+}
+
+func (e *FieldConversionError) Unwrap() error {
+	return e.Err
+}
+
+// StrictJSONError 严格模式下请求体包含未知或重复的键，路径形如 "items[0].nmae"
+type StrictJSONError struct {
+	UnknownFields   []string
+	DuplicateFields []string
+}
+
+func (e *StrictJSONError) Error() string {
+	var messages []string
+	if len(e.UnknownFields) > 0 {
+		messages = append(messages, fmt.Sprintf("unknown fields [%s]", strings.Join(e.UnknownFields, ", ")))
+	}
+	if len(e.DuplicateFields) > 0 {
+		messages = append(messages, fmt.Sprintf("duplicate fields [%s]", strings.Join(e.DuplicateFields, ", ")))
+	}
+	return "body has " + strings.Join(messages, " and ")
+}
+
+// BodyTooLargeError 请求体超过了允许的最大字节数
+type BodyTooLargeError struct {
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("request body exceeds %d bytes", e.Limit)
+}
+
+// UnsupportedMediaTypeError 请求体的 Content-Type 没有对应的解码器
+type UnsupportedMediaTypeError struct {
+	MediaType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported media type: %s", e.MediaType)
+}
+
+// TagError 请求类型的标签无法解析（如 time 标签中不存在的时区），属于服务端的定义错误而不是客户端的输入错误
+type TagError struct {
+	Type  reflect.Type // 字段所属的结构体类型
+	Field string       // Go 字段名
+	Tag   string       // 标签名
+	Value string       // 标签内容
+	Err   error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("invalid %s tag %q on %v.%s: %v", e.Tag, e.Value, e.Type, e.Field, e.Err)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode 返回绑定错误对应的 HTTP 状态码：请求体过大为 413，不支持的 Content-Type 为 415，
+// 请求类型的标签无法解析为 500，其余错误（解码、类型转换、校验失败等）为 400
+func StatusCode(err error) int {
+	var tooLarge *BodyTooLargeError
+	var unsupported *UnsupportedMediaTypeError
+	var tagErr *TagError
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.As(err, &tagErr):
+		return http.StatusInternalServerError
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.As(err, &unsupported):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// conversionError 构造字段的类型转换错误，path 为字段在根结构体中的路径
+func (f *fieldPlan) conversionError(path string, source Source, value string, err error) *FieldConversionError {
+	name := f.sourceName(source, f.jsonName, "json")
+	if name == "" {
+		name = f.name
+	}
+	return &FieldConversionError{
+		Field:  path,
+		Name:   name,
+		Source: source,
+		Value:  value,
+		Type:   f.typ,
+		Err:    err,
+	}
+}
@@ -0,0 +1,341 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestValidationErrorFields 测试结构化校验错误中的字段信息
+func TestValidationErrorFields(t *testing.T) {
+	type item struct {
+		Sku string `json:"sku" v:"required"`
+	}
+	type BaseReq struct {
+		TraceId *string `header:"traceId,omitempty" v:"required"`
+	}
+	type testStruct struct {
+		BaseReq `cv:"true"`
+		StoreId string  `url:"storeId" v:"len=4"`
+		Page    int     `param:"page" v:"max=10"`
+		UserId  *string `json:"userId,omitempty" v:"required"`
+		Items   []item  `json:"items" v:"dive"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test?page=20", bytes.NewBufferString(`{"items":[{"sku":"a"},{}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = withURLParams(req, map[string]string{"storeId": "s-1"})
+
+	_, parserResult, err := Valid[testStruct](req)
+	if parserResult != ParserResultNotVerified {
+		t.Fatalf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+
+	expected := map[string]FieldError{
+		"BaseReq.TraceId": {Name: "traceId", Source: SourceHeader, Rule: "required"},
+		"StoreId":         {Name: "storeId", Source: SourcePath, Rule: "len", Param: "4", Value: "s-1"},
+		"Page":            {Name: "page", Source: SourceQuery, Rule: "max", Param: "10", Value: 20},
+		"UserId":          {Name: "userId", Source: SourceBody, Rule: "required"},
+		"Items[1].Sku":    {Name: "items[1].sku", Source: SourceBody, Rule: "required", Value: ""},
+	}
+	if len(validationErr.Errors) != len(expected) {
+		t.Fatalf("expected %d field errors, got %d: %v", len(expected), len(validationErr.Errors), validationErr)
+	}
+	for _, fe := range validationErr.Errors {
+		want, ok := expected[fe.Field]
+		if !ok {
+			t.Errorf("unexpected field error: %+v", fe)
+			continue
+		}
+		if fe.Name != want.Name || fe.Source != want.Source || fe.Rule != want.Rule || fe.Param != want.Param {
+			t.Errorf("%s: expected %+v, got %+v", fe.Field, want, fe)
+		}
+		if want.Value != nil && fe.Value != want.Value {
+			t.Errorf("%s: expected value %v, got %v", fe.Field, want.Value, fe.Value)
+		}
+	}
+}
+
+// TestValidationErrorActualSource 测试字段声明了多个来源时，报告实际使用的来源
+func TestValidationErrorActualSource(t *testing.T) {
+	type testStruct struct {
+		Platform string `json:"platform" header:"X-Platform" param:"platform" v:"oneof=ios android"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?platform=web", nil)
+	_, validation, err := ParseWithValidation[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validation.Error == nil || len(validation.Error.Errors) != 1 {
+		t.Fatalf("expected one field error, got %v", validation.Error)
+	}
+	if fe := validation.Error.Errors[0]; fe.Source != SourceQuery || fe.Name != "platform" || fe.Value != "web" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+
+	req, _ = http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Platform", "web")
+	_, validation, _ = ParseWithValidation[testStruct](req)
+	if fe := validation.Error.Errors[0]; fe.Source != SourceHeader || fe.Name != "X-Platform" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+
+	// 没有任何来源时，报告优先级最高的声明来源
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{}`))
+	_, validation, _ = ParseWithValidation[testStruct](req)
+	if fe := validation.Error.Errors[0]; fe.Source != SourceBody || fe.Name != "platform" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+}
+
+// TestValidationErrorNilWhenValid 测试校验通过时没有结构化错误
+func TestValidationErrorNilWhenValid(t *testing.T) {
+	type testStruct struct {
+		Name string `param:"name" v:"required"`
+	}
+	req, _ := http.NewRequest("GET", "/test?name=a", nil)
+	_, validation, err := ParseWithValidation[testStruct](req)
+	if err != nil || validation.Error != nil {
+		t.Errorf("expected no errors, got %v, %v", err, validation.Error)
+	}
+}
+
+// TestValidationErrorMessages 测试错误信息使用客户端的参数名和位置
+func TestValidationErrorMessages(t *testing.T) {
+	type BaseReq struct {
+		TraceId *string `header:"traceId,omitempty" v:"required"`
+	}
+	type TranferStoreReq struct {
+		BaseReq `cv:"true"`
+		StoreId *string `url:"storeId" v:"required"`
+		Page    int     `param:"page" default:"1" v:"max=10"`
+		UserId  *string `json:"userId,omitempty" v:"required"`
+		Type    string  `json:"type" v:"oneof=move copy"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test?page=11", bytes.NewBufferString(`{"type":"drop"}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, validation, err := ParseWithValidation[TranferStoreReq](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"header traceId is required",
+		"path storeId is required",
+		"query page must be at most 10",
+		"body userId is required",
+		"body type must be one of [move copy]",
+	}
+	if len(validation.Error.Errors) != len(expected) {
+		t.Fatalf("expected %d field errors, got %v", len(expected), validation.Error)
+	}
+	for i, fe := range validation.Error.Errors {
+		if fe.Error() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], fe.Error())
+		}
+	}
+	if *validation.ValidMessage != "header traceId is required,path storeId is required,query page must be at most 10,body userId is required,body type must be one of [move copy]," {
+		t.Errorf("unexpected ValidMessage: %s", *validation.ValidMessage)
+	}
+	if got := validation.Error.Error(); got != "header traceId is required; path storeId is required; query page must be at most 10; body userId is required; body type must be one of [move copy]" {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+// TestFieldConversionError 测试参数类型转换失败时返回的错误
+func TestFieldConversionError(t *testing.T) {
+	type testStruct struct {
+		Page  int     `param:"page"`
+		Limit *int    `header:"X-Limit"`
+		Debug bool    `param:"debug" default:"maybe"`
+		Id    int64   `url:"id"`
+		Age   int8    `param:"age"`
+		Count uint8   `header:"X-Count"`
+		Ratio float32 `param:"ratio"`
+	}
+
+	tests := []struct {
+		name     string
+		request  func() *http.Request
+		expected FieldConversionError
+	}{
+		{
+			name: "query",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test?page=abc&debug=true", nil)
+				return req
+			},
+			expected: FieldConversionError{Field: "Page", Name: "page", Source: SourceQuery, Value: "abc", Type: reflect.TypeOf(0)},
+		},
+		{
+			name: "header",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("POST", "/test?debug=true", nil)
+				req.Header.Set("X-Limit", "ten")
+				return req
+			},
+			expected: FieldConversionError{Field: "Limit", Name: "X-Limit", Source: SourceHeader, Value: "ten", Type: reflect.TypeOf(new(int))},
+		},
+		{
+			name: "path",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test?debug=true", nil)
+				return withURLParams(req, map[string]string{"id": "x1"})
+			},
+			expected: FieldConversionError{Field: "Id", Name: "id", Source: SourcePath, Value: "x1", Type: reflect.TypeOf(int64(0))},
+		},
+		{
+			name: "int_overflow",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test?age=300&debug=true", nil)
+				return req
+			},
+			expected: FieldConversionError{Field: "Age", Name: "age", Source: SourceQuery, Value: "300", Type: reflect.TypeOf(int8(0))},
+		},
+		{
+			name: "uint_overflow",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test?debug=true", nil)
+				req.Header.Set("X-Count", "256")
+				return req
+			},
+			expected: FieldConversionError{Field: "Count", Name: "X-Count", Source: SourceHeader, Value: "256", Type: reflect.TypeOf(uint8(0))},
+		},
+		{
+			name: "float_overflow",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test?ratio=1e39&debug=true", nil)
+				return req
+			},
+			expected: FieldConversionError{Field: "Ratio", Name: "ratio", Source: SourceQuery, Value: "1e39", Type: reflect.TypeOf(float32(0))},
+		},
+		{
+			name: "default",
+			request: func() *http.Request {
+				req, _ := http.NewRequest("GET", "/test", nil)
+				return req
+			},
+			expected: FieldConversionError{Field: "Debug", Name: "Debug", Source: SourceDefault, Value: "maybe", Type: reflect.TypeOf(false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, parserResult, err := Valid[testStruct](tt.request())
+			if parserResult != ParserResultError {
+				t.Fatalf("expected ParserResultError, got %v", parserResult)
+			}
+			var conversionErr *FieldConversionError
+			if !errors.As(err, &conversionErr) {
+				t.Fatalf("expected *FieldConversionError, got %T: %v", err, err)
+			}
+			got := *conversionErr
+			got.Err = nil
+			if got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+			var numErr *strconv.NumError
+			if tt.name != "default" && !errors.As(err, &numErr) {
+				t.Errorf("expected underlying *strconv.NumError, got %v", conversionErr.Err)
+			}
+			if StatusCode(err) != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", StatusCode(err))
+			}
+		})
+	}
+}
+
+// TestDecodeError 测试请求体格式错误时返回的错误
+func TestDecodeError(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	_, _, err := Valid[testStruct](req)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %T: %v", err, err)
+	}
+	if decodeErr.MediaType != "application/json" {
+		t.Errorf("expected media type application/json, got %s", decodeErr.MediaType)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected underlying io.ErrUnexpectedEOF, got %v", decodeErr.Err)
+	}
+	if StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", StatusCode(err))
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":x}`))
+	_, _, err = Valid[testStruct](req)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &decodeErr) || !errors.As(err, &syntaxErr) {
+		t.Errorf("expected *DecodeError wrapping *json.SyntaxError, got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":`))
+	if _, err := ReadRequestBody[testStruct](req); !errors.As(err, &decodeErr) {
+		t.Errorf("expected ReadRequestBody to return *DecodeError, got %T", err)
+	}
+}
+
+// TestBodyTooLargeError 测试请求体超过限制时返回的错误
+func TestBodyTooLargeError(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	b := NewBinder(WithMaxBodySize(8))
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"long enough"}`))
+	_, _, err := Bind[testStruct](b, req)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 8 {
+		t.Fatalf("expected *BodyTooLargeError with limit 8, got %T: %v", err, err)
+	}
+	if StatusCode(err) != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", StatusCode(err))
+	}
+
+	// 上游 http.MaxBytesReader 的限制同样识别
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"long enough"}`))
+	req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 4)
+	_, _, err = Valid[testStruct](req)
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 4 {
+		t.Fatalf("expected *BodyTooLargeError with limit 4, got %T: %v", err, err)
+	}
+}
+
+// TestUnsupportedMediaTypeError 测试不支持的 Content-Type
+func TestUnsupportedMediaTypeError(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`name: a`))
+	req.Header.Set("Content-Type", "text/x-unknown")
+	_, _, err := Valid[testStruct](req)
+	var unsupported *UnsupportedMediaTypeError
+	if !errors.As(err, &unsupported) || unsupported.MediaType != "text/x-unknown" {
+		t.Fatalf("expected *UnsupportedMediaTypeError, got %T: %v", err, err)
+	}
+	if StatusCode(err) != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status 415, got %d", StatusCode(err))
+	}
+
+	// +json 结构化后缀按 JSON 处理
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a"}`))
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	if result, _, err := Valid[testStruct](req); err != nil || result.Name != "a" {
+		t.Errorf("expected +json body to be decoded, got %+v, %v", result, err)
+	}
+}
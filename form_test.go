@@ -0,0 +1,155 @@
+package chttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newFormRequest(target string, values url.Values) *http.Request {
+	req, _ := http.NewRequest("POST", target, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// TestFormBinding 测试 application/x-www-form-urlencoded 表单绑定
+func TestFormBinding(t *testing.T) {
+	type testStruct struct {
+		Name     string   `form:"name" v:"required"`
+		Age      *int     `form:"age"`
+		Score    float64  `form:"score"`
+		Agree    bool     `form:"agree" default:"true"`
+		Channel  string   `form:"channel" default:"web"`
+		Page     int      `param:"page" default:"1"`
+		Remark   *string  `form:"remark"`
+		Verified *bool    `form:"verified" default:"false"`
+		Ratio    *float32 `form:"ratio"`
+	}
+
+	req := newFormRequest("/test?page=2", url.Values{
+		"name":     {"alice"},
+		"age":      {"30"},
+		"score":    {"98.5"},
+		"agree":    {"false"},
+		"verified": {"true"},
+	})
+	result, parserResult, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	if result.Name != "alice" || result.Age == nil || *result.Age != 30 || result.Score != 98.5 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	// 表单传入的 false 不会被默认值覆盖
+	if result.Agree != false {
+		t.Errorf("expected Agree to be false, got %v", result.Agree)
+	}
+	if result.Verified == nil || *result.Verified != true {
+		t.Errorf("expected Verified to be true, got %v", result.Verified)
+	}
+	if result.Channel != "web" || result.Page != 2 {
+		t.Errorf("expected defaults and query params to apply, got %+v", result)
+	}
+	if result.Remark != nil || result.Ratio != nil {
+		t.Errorf("expected missing form fields to remain nil, got %+v", result)
+	}
+
+	// 默认不保留请求体，开启 WithReusableBody 后可以重复读取
+	if body, _ := io.ReadAll(req.Body); len(body) != 0 {
+		t.Errorf("expected body to be consumed, got %q", body)
+	}
+	req = newFormRequest("/test", url.Values{"name": {"alice"}})
+	if _, _, err := Bind[testStruct](NewBinder(WithReusableBody()), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if !strings.Contains(string(body), "name=alice") {
+		t.Errorf("expected body to be re-readable, got %q", body)
+	}
+}
+
+// TestFormPriority 测试表单字段的优先级：高于 query 和 header，低于 URL 路径参数
+func TestFormPriority(t *testing.T) {
+	type testStruct struct {
+		Mode string `form:"mode" param:"mode" header:"X-Mode" url:"mode"`
+	}
+
+	req := newFormRequest("/test?mode=query", url.Values{"mode": {"form"}})
+	req.Header.Set("X-Mode", "header")
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Mode != "form" {
+		t.Errorf("expected Mode to be 'form', got %q", result.Mode)
+	}
+
+	req = newFormRequest("/test", url.Values{"mode": {"form"}})
+	req = withURLParams(req, map[string]string{"mode": "path"})
+	result, _, _ = Valid[testStruct](req)
+	if result.Mode != "path" {
+		t.Errorf("expected Mode to be 'path', got %q", result.Mode)
+	}
+}
+
+// TestFormParamFallback 测试没有 form 标签时使用 param 标签读取表单
+func TestFormParamFallback(t *testing.T) {
+	type testStruct struct {
+		Keyword string `param:"q" v:"required"`
+		Size    int    `param:"size" form:"limit"`
+	}
+
+	req := newFormRequest("/test", url.Values{"q": {"shoes"}, "limit": {"5"}, "size": {"9"}})
+	if _, parserResult, _ := Valid[testStruct](req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected default binder to ignore form values without form tag, got %v", parserResult)
+	}
+
+	b := NewBinder(WithFormParamFallback())
+	req = newFormRequest("/test", url.Values{"q": {"shoes"}, "limit": {"5"}, "size": {"9"}})
+	result, parserResult, err := Bind[testStruct](b, req)
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+	if result.Keyword != "shoes" || result.Size != 5 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// TestFormErrors 测试表单字段的转换与校验错误
+func TestFormErrors(t *testing.T) {
+	type testStruct struct {
+		Age  int    `form:"age"`
+		Name string `form:"name" v:"required"`
+	}
+
+	req := newFormRequest("/test", url.Values{"age": {"old"}, "name": {"a"}})
+	_, _, err := Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Source != SourceForm || conversionErr.Name != "age" {
+		t.Fatalf("expected form *FieldConversionError, got %T: %v", err, err)
+	}
+
+	req = newFormRequest("/test", url.Values{"age": {"3"}})
+	_, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	if msg := validationErr.Errors[0].Error(); msg != "form name is required" {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader("age=%zz"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	_, _, err = Valid[testStruct](req)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,767 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const jsonMediaType = "application/json"
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// StrictJSONMode 由请求类型实现，单独开启或关闭严格的 JSON 解码，优先于 WithStrictJSON
+type StrictJSONMode interface {
+	StrictJSON() bool
+}
+
+// WithStrictJSON 开启严格的 JSON 解码：请求体中没有对应字段的键、同一对象中重复的键都会返回 StrictJSONError。
+// 作为整体解码的值（any、json.RawMessage、实现 json.Unmarshaler 的类型）只检查未知字段
+func WithStrictJSON() Option {
+	return func(b *Binder) {
+		b.strictJSON = true
+	}
+}
+
+// IsStrictJSON 解码到 v 时是否使用严格模式，v 实现 StrictJSONMode 时优先使用其返回值
+func (b *Binder) IsStrictJSON(v any) bool {
+	if mode, ok := v.(StrictJSONMode); ok {
+		return mode.StrictJSON()
+	}
+	return b.strictJSON
+}
+
+// jsonBodyDecoder 默认的 JSON 请求体解码器，支持灵活的时间格式
+type jsonBodyDecoder struct {
+	binder *Binder
+}
+
+// Decode 按 token 流一次遍历请求体：按解析计划写入字段、记录出现过的字段路径，并按字段的规则解析时间
+func (d *jsonBodyDecoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	return d.binder.DecodeJSON(r, v, present, BodyFormat{MediaType: jsonMediaType, KeyTag: "json"})
+}
+
+// BodyKeyTag 请求体的键名使用 json 标签
+func (d *jsonBodyDecoder) BodyKeyTag() string {
+	return "json"
+}
+
+// BodyFormat 按 JSON 规则解码的请求体格式，YAML、TOML 等格式先转换为 JSON 再交给 DecodeJSON
+type BodyFormat struct {
+	MediaType  string // 报告错误使用的 media type
+	KeyTag     string // 匹配键名使用的标签（json、yaml、toml 等），没有该标签的字段使用 json 标签的名称
+	NativeTime bool   // 格式自带时间类型，转换后为 RFC3339 字符串，不受字段 time 标签的格式限制
+}
+
+// DecodeJSON 按 token 流将 JSON 解码到 v，键名匹配、时间格式、严格模式与 JSON 请求体一致，
+// 出现过的字段路径记录在 present 中。供其他格式的 BodyDecoder 在转换为 JSON 后使用
+func (b *Binder) DecodeJSON(r io.Reader, v any, present map[string]bool, format BodyFormat) error {
+	dec := json.NewDecoder(r)
+	// 整数按原始文本转换，避免经过 float64 丢失精度
+	dec.UseNumber()
+	s := &jsonStream{binder: b, dec: dec, present: present, format: format, strict: b.IsStrictJSON(v)}
+	if err := s.decodeValue(reflect.ValueOf(v).Elem(), nil); err != nil {
+		return err
+	}
+	offset := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		return &DecodeError{MediaType: format.MediaType, Err: fmt.Errorf("invalid data after top-level value at offset %d", offset)}
+	}
+	if len(s.unknown) > 0 || len(s.duplicate) > 0 {
+		return &StrictJSONError{UnknownFields: s.unknown, DuplicateFields: s.duplicate}
+	}
+	return nil
+}
+
+// DecodeGeneric 将其他格式解码得到的通用值（map、切片、基础类型与 time.Time）转换为 JSON 后交给 DecodeJSON，
+// 键不是字符串的 map 按 fmt.Sprint 转换键名
+func (b *Binder) DecodeGeneric(doc any, v any, present map[string]bool, format BodyFormat) error {
+	data, err := json.Marshal(jsonCompatible(doc))
+	if err != nil {
+		return &DecodeError{MediaType: format.MediaType, Err: errors.Wrap(err, "cannot convert body to JSON")}
+	}
+	return b.DecodeJSON(bytes.NewReader(data), v, present, format)
+}
+
+// jsonCompatible 将键不是字符串的 map 转换为 map[string]any，使其可以编码为 JSON
+func jsonCompatible(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(elem)
+		}
+		return m
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = jsonCompatible(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = jsonCompatible(elem)
+		}
+	}
+	return v
+}
+
+// jsonStream 一次 JSON 解码的状态
+type jsonStream struct {
+	binder  *Binder
+	dec     *json.Decoder
+	present map[string]bool
+	path    []jsonPathSegment             // 当前值在请求体中的路径，只在出错时拼接成字符串
+	prefix  string                        // 当前对象在根结构体中的字段路径，如 "Items."
+	paths   map[*fieldPlan]*jsonFieldPath // 见 fieldPath
+	depth   int                           // 当前嵌套结构体的层数
+	format  BodyFormat
+
+	strict    bool
+	unknown   []string // 严格模式下未知键的路径
+	duplicate []string // 严格模式下重复键的路径
+}
+
+// jsonFieldPath 字段在某个前缀下拼接好的路径，数组中的元素重复使用，避免每个元素重新拼接
+type jsonFieldPath struct {
+	prefix string
+	path   string // 字段路径，如 "Items.Name"
+	nested string // 嵌套结构体中字段的前缀，如 "Items."
+}
+
+// fieldPath 返回字段 f 在当前前缀下的路径
+func (s *jsonStream) fieldPath(f *fieldPlan) *jsonFieldPath {
+	if p, ok := s.paths[f]; ok && p.prefix == s.prefix {
+		return p
+	}
+	if s.paths == nil {
+		s.paths = make(map[*fieldPlan]*jsonFieldPath)
+	}
+	path := s.prefix + f.name
+	p := &jsonFieldPath{prefix: s.prefix, path: path, nested: path + "."}
+	s.paths[f] = p
+	return p
+}
+
+// jsonPathSegment 路径中的一段：对象的键（index 为 -1），或数组下标
+type jsonPathSegment struct {
+	key   string
+	index int
+}
+
+// pathString 返回形如 "items[1].qty" 的路径
+func (s *jsonStream) pathString() string {
+	var sb strings.Builder
+	for _, seg := range s.path {
+		if seg.index >= 0 {
+			sb.WriteString("[" + strconv.Itoa(seg.index) + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(seg.key)
+	}
+	return sb.String()
+}
+
+// keyPath 返回当前对象中键 key 的路径
+func (s *jsonStream) keyPath(key string) string {
+	s.path = append(s.path, jsonPathSegment{key: key, index: -1})
+	path := s.pathString()
+	s.path = s.path[:len(s.path)-1]
+	return path
+}
+
+// checkDuplicate 严格模式下记录同一对象中重复出现的键，name 为键对应的字段名或原始键，键重复时返回 true
+func (s *jsonStream) checkDuplicate(seen map[string]bool, name, key string) bool {
+	if seen == nil {
+		return false
+	}
+	if seen[name] {
+		s.duplicate = append(s.duplicate, s.keyPath(key))
+		return true
+	}
+	seen[name] = true
+	return false
+}
+
+// keyFields 返回按 tag 标签命名的键到字段链的索引，嵌入结构体与 inline 字段的字段提升到外层，外层同名字段优先。
+// tag 不是 json 时，没有该标签的字段沿用 json 标签的名称
+func (p *typePlan) keyFields(tag string) map[string][]*fieldPlan {
+	if index, ok := p.keyIndexes.Load(tag); ok {
+		return index.(map[string][]*fieldPlan)
+	}
+	index := make(map[string][]*fieldPlan)
+	var inline []*fieldPlan
+	for _, f := range p.fields {
+		name, skip, isInline := f.keyName(tag)
+		switch {
+		case skip:
+		case isInline:
+			inline = append(inline, f)
+		case f.settable:
+			// 未导出的字段无法赋值，包括未导出的嵌入指针与嵌入的非结构体类型，与 encoding/json 一致不参与匹配
+			index[name] = []*fieldPlan{f}
+		}
+	}
+	for _, f := range inline {
+		for key, chain := range f.nested().keyFields(tag) {
+			if _, ok := index[key]; !ok {
+				index[key] = append([]*fieldPlan{f}, chain...)
+			}
+		}
+	}
+	actual, _ := p.keyIndexes.LoadOrStore(tag, index)
+	return actual.(map[string][]*fieldPlan)
+}
+
+// keyName 返回字段在 tag 标签下的键名，以及是否跳过、是否将其字段提升到外层（yaml:",inline"）。
+// 带 ctx 标签的字段只接收服务端写入的上下文值，任何层级都不从请求体读取
+func (f *fieldPlan) keyName(tag string) (name string, skip, inline bool) {
+	if f.ctx != "" {
+		return "", true, false
+	}
+	if tag != "json" {
+		if value, ok := f.tag.Lookup(tag); ok {
+			name, opts, _ := strings.Cut(value, ",")
+			if name == "-" {
+				return "", true, false
+			}
+			if hasTagOption(opts, "inline") && f.settable && isStructType(f.typ) {
+				return "", false, true
+			}
+			if name != "" {
+				return name, false, false
+			}
+		}
+	}
+	return f.jsonName, f.jsonSkip, f.jsonInline
+}
+
+// isStructType 结构体或结构体指针
+func isStructType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
+// lookupField 按键查找字段，返回索引中的键名与字段链。与 encoding/json 一致：先精确匹配，再忽略大小写匹配
+func (p *typePlan) lookupField(tag, key string) (string, []*fieldPlan) {
+	fields := p.keyFields(tag)
+	if chain, ok := fields[key]; ok {
+		return key, chain
+	}
+	for name, chain := range fields {
+		if strings.EqualFold(name, key) {
+			return name, chain
+		}
+	}
+	return "", nil
+}
+
+// jsonValueKind 决定某个类型的 JSON 值如何解码
+type jsonValueKind int
+
+const (
+	jsonComposite jsonValueKind = iota // 对象、数组按元素逐个解码
+	jsonToken                          // 基础类型与时间，直接使用 token
+	jsonRaw                            // 其余类型作为整体交给 encoding/json 或参数解析规则
+)
+
+// jsonKindOf 返回类型 t 的解码方式，结果按类型缓存
+func (b *Binder) jsonKindOf(t reflect.Type) jsonValueKind {
+	if kind, ok := b.jsonKinds.Load(t); ok {
+		return kind.(jsonValueKind)
+	}
+	base := t
+	for base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	kind := jsonRaw
+	switch {
+	case base == timeType:
+		kind = jsonToken
+	case b.isScalar(base) || reflect.PointerTo(base).Implements(jsonUnmarshalerType):
+	case isBasicKind(base.Kind()):
+		kind = jsonToken
+	case base.Kind() == reflect.Struct, base.Kind() == reflect.Array:
+		kind = jsonComposite
+	case base.Kind() == reflect.Slice && base.Elem().Kind() != reflect.Uint8:
+		kind = jsonComposite
+	case base.Kind() == reflect.Map && base.Key().Kind() == reflect.String:
+		kind = jsonComposite
+	}
+	b.jsonKinds.Store(t, kind)
+	return kind
+}
+
+// decodeValue 将下一个 JSON 值写入 v，f 为 v 所属的字段（顶层为 nil），path 为该值在请求体中的路径
+func (s *jsonStream) decodeValue(v reflect.Value, f *fieldPlan) error {
+	if s.binder.jsonKindOf(v.Type()) != jsonComposite {
+		return s.decodeLeaf(v, f)
+	}
+	tok, err := s.dec.Token()
+	if err != nil {
+		return s.syntaxError(err)
+	}
+	if tok == nil {
+		// 与 encoding/json 一致：null 将指针、切片、map 置空，其余类型保持不变
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if tok != json.Delim('{') {
+			return s.typeError(f, tok, v.Type())
+		}
+		plan := s.binder.planFor(v.Type())
+		if f == nil {
+			return s.decodeObject(v, plan)
+		}
+		if s.depth >= maxNestingDepth {
+			return &DecodeError{MediaType: s.format.MediaType, Err: fmt.Errorf("exceeded max nesting depth %d at %s", maxNestingDepth, s.pathString())}
+		}
+		prefix := s.prefix
+		s.prefix = s.fieldPath(f).nested
+		s.depth++
+		err := s.decodeObject(v, f.nested())
+		s.prefix = prefix
+		s.depth--
+		return err
+	case reflect.Slice, reflect.Array:
+		if tok != json.Delim('[') {
+			return s.typeError(f, tok, v.Type())
+		}
+		return s.decodeArray(v, f)
+	default:
+		if tok != json.Delim('{') {
+			return s.typeError(f, tok, v.Type())
+		}
+		return s.decodeMap(v, f)
+	}
+}
+
+// decodeObject 解码 JSON 对象到结构体，'{' 已被读取
+func (s *jsonStream) decodeObject(v reflect.Value, plan *typePlan) error {
+	var seen map[string]bool
+	if s.strict {
+		seen = make(map[string]bool)
+	}
+	for s.dec.More() {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return s.syntaxError(err)
+		}
+		key := tok.(string)
+		name, chain := plan.lookupField(s.format.KeyTag, key)
+		if chain == nil {
+			if s.strict && !s.checkDuplicate(seen, key, key) {
+				s.unknown = append(s.unknown, s.keyPath(key))
+			}
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		field, prefix := v, s.prefix
+		for _, f := range chain[:len(chain)-1] {
+			// 提升到外层的字段，路径中保留嵌入结构体的字段名
+			s.prefix = s.fieldPath(f).nested
+			field = field.Field(f.index)
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					field.Set(reflect.New(f.typ.Elem()))
+				}
+				field = field.Elem()
+			}
+		}
+		f := chain[len(chain)-1]
+		// 忽略大小写匹配到同一字段的键同样视为重复
+		s.checkDuplicate(seen, name, key)
+		s.present[s.fieldPath(f).path] = true
+		s.path = append(s.path, jsonPathSegment{key: name, index: -1})
+		if err := s.decodeValue(field.Field(f.index), f); err != nil {
+			return err
+		}
+		s.path = s.path[:len(s.path)-1]
+		s.prefix = prefix
+	}
+	return s.readDelim('}')
+}
+
+// decodeArray 解码 JSON 数组到切片或数组，'[' 已被读取，多出的元素被忽略
+func (s *jsonStream) decodeArray(v reflect.Value, f *fieldPlan) error {
+	isSlice := v.Kind() == reflect.Slice
+	if isSlice {
+		v.SetLen(0)
+	}
+	i := 0
+	for ; s.dec.More(); i++ {
+		if isSlice {
+			if i >= v.Cap() {
+				// 按倍数扩容，避免逐个元素 reflect.Append
+				grown := reflect.MakeSlice(v.Type(), i, 2*i+4)
+				reflect.Copy(grown, v)
+				v.Set(grown)
+			}
+			v.SetLen(i + 1)
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		} else if i >= v.Len() {
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		s.path = append(s.path, jsonPathSegment{index: i})
+		if err := s.decodeValue(v.Index(i), f); err != nil {
+			return err
+		}
+		s.path = s.path[:len(s.path)-1]
+	}
+	if isSlice && v.IsNil() {
+		// 空数组得到非 nil 的空切片
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+	for ; !isSlice && i < v.Len(); i++ {
+		v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+	}
+	return s.readDelim(']')
+}
+
+// decodeMap 解码 JSON 对象到键为字符串的 map，'{' 已被读取
+func (s *jsonStream) decodeMap(v reflect.Value, f *fieldPlan) error {
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	var seen map[string]bool
+	if s.strict {
+		seen = make(map[string]bool)
+	}
+	for s.dec.More() {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return s.syntaxError(err)
+		}
+		key := tok.(string)
+		s.checkDuplicate(seen, key, key)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		s.path = append(s.path, jsonPathSegment{key: key, index: -1})
+		if err := s.decodeValue(elem, f); err != nil {
+			return err
+		}
+		s.path = s.path[:len(s.path)-1]
+		v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+	}
+	return s.readDelim('}')
+}
+
+// decodeLeaf 解码作为整体处理的值：基础类型直接使用 token，时间按字段规则解析，其余交给 encoding/json
+func (s *jsonStream) decodeLeaf(v reflect.Value, f *fieldPlan) error {
+	t := v.Type()
+	if s.binder.jsonKindOf(t) == jsonToken {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return s.syntaxError(err)
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			// 对象或数组不能写入基础类型，跳过其余部分后报错
+			if err := s.skipRest(delim); err != nil {
+				return err
+			}
+			return s.typeError(f, tok, t)
+		}
+		return s.setToken(v, f, tok)
+	}
+
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		return s.syntaxError(err)
+	}
+	base := t
+	for base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	if raw[0] == '"' && s.usesParamDecoder(base) {
+		// 注册了 TypeDecoder 或只实现了 ParamUnmarshaler 的类型，字符串按参数的规则解析
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return s.fieldError(f, string(raw), err)
+		}
+		if err := s.binder.compileSetter(t, s.timeFormat(f))(v, str); err != nil {
+			return s.fieldError(f, str, err)
+		}
+		return nil
+	}
+	if err := s.unmarshalRaw(raw, v.Addr().Interface()); err != nil {
+		return s.fieldError(f, string(raw), err)
+	}
+	return nil
+}
+
+// unmarshalRaw 使用 encoding/json 解码作为整体处理的值，any、map[string]any 等中的数字解码为 json.Number，
+// 避免经过 float64 丢失精度；严格模式下不允许未知字段
+func (s *jsonStream) unmarshalRaw(raw json.RawMessage, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if s.strict {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+// usesParamDecoder 类型的 JSON 字符串是否需要按参数的规则解析
+func (s *jsonStream) usesParamDecoder(t reflect.Type) bool {
+	if _, ok := s.binder.typeDecoders[t]; ok {
+		return true
+	}
+	pt := reflect.PointerTo(t)
+	return pt.Implements(paramUnmarshalerType) && !pt.Implements(jsonUnmarshalerType) && !pt.Implements(textUnmarshalerType)
+}
+
+// setToken 将基础类型或时间的 token 写入 v
+func (s *jsonStream) setToken(v reflect.Value, f *fieldPlan, tok json.Token) error {
+	if f != nil && f.jsonString && tok != nil {
+		var err error
+		if tok, err = s.unquoteToken(f, tok); err != nil {
+			return err
+		}
+	}
+	if tok == nil {
+		if v.Kind() == reflect.Ptr {
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	t := v.Type()
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		var str string
+		switch value := tok.(type) {
+		case string:
+			str = value
+		case json.Number:
+			str = value.String()
+		default:
+			return s.typeError(f, tok, t)
+		}
+		parsed, err := s.parseTime(f, str)
+		if err != nil {
+			return s.fieldError(f, str, err)
+		}
+		v.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	switch value := tok.(type) {
+	case string:
+		if v.Kind() != reflect.String {
+			return s.typeError(f, tok, t)
+		}
+		v.SetString(value)
+	case bool:
+		if v.Kind() != reflect.Bool {
+			return s.typeError(f, tok, t)
+		}
+		v.SetBool(value)
+	case json.Number:
+		var err error
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			if n, err = strconv.ParseInt(value.String(), 10, 64); err == nil && v.OverflowInt(n) {
+				err = fmt.Errorf("value %s overflows %v", value, v.Type())
+			}
+			if err == nil {
+				v.SetInt(n)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			var n uint64
+			if n, err = strconv.ParseUint(value.String(), 10, 64); err == nil && v.OverflowUint(n) {
+				err = fmt.Errorf("value %s overflows %v", value, v.Type())
+			}
+			if err == nil {
+				v.SetUint(n)
+			}
+		case reflect.Float32, reflect.Float64:
+			var n float64
+			if n, err = strconv.ParseFloat(value.String(), v.Type().Bits()); err == nil {
+				v.SetFloat(n)
+			}
+		default:
+			return s.typeError(f, tok, t)
+		}
+		if err != nil {
+			return s.fieldError(f, value.String(), err)
+		}
+	}
+	return nil
+}
+
+// unquoteToken 取出 json:",string" 字段的字符串中的 JSON 值，与 encoding/json 一致只接受字符串形式的值
+func (s *jsonStream) unquoteToken(f *fieldPlan, tok json.Token) (json.Token, error) {
+	str, ok := tok.(string)
+	if !ok {
+		return nil, s.fieldError(f, fmt.Sprint(tok), fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal unquoted value into %v", f.typ))
+	}
+	dec := json.NewDecoder(strings.NewReader(str))
+	dec.UseNumber()
+	inner, err := dec.Token()
+	if _, isDelim := inner.(json.Delim); err != nil || isDelim {
+		return nil, s.fieldError(f, str, fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal %q into %v", str, f.typ))
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, s.fieldError(f, str, fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal %q into %v", str, f.typ))
+	}
+	return inner, nil
+}
+
+// isQuotableType json:",string" 适用的类型：字符串、数字、布尔值及其指针
+func isQuotableType(t reflect.Type) bool {
+	if t.Name() == "" && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// parseTime 按字段的规则解析时间，格式自带的时间类型（RFC3339 字符串）优先
+func (s *jsonStream) parseTime(f *fieldPlan, value string) (time.Time, error) {
+	tf := s.timeFormat(f)
+	if s.format.NativeTime {
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			if tf.loc != nil {
+				t = t.In(tf.loc)
+			}
+			return t, nil
+		}
+	}
+	return tf.parseTime(value)
+}
+
+// timeFormat 返回字段的时间解析规则，顶层值使用 Binder 的默认规则
+func (s *jsonStream) timeFormat(f *fieldPlan) *timeFormat {
+	if f == nil {
+		return s.binder.defaultTime
+	}
+	return f.timeFormat
+}
+
+// skipValue 跳过下一个 JSON 值
+func (s *jsonStream) skipValue() error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return s.syntaxError(err)
+	}
+	if delim, ok := tok.(json.Delim); ok {
+		return s.skipRest(delim)
+	}
+	return nil
+}
+
+// skipRest 跳过已读取 delim 的对象或数组的剩余部分
+func (s *jsonStream) skipRest(delim json.Delim) error {
+	if delim != '{' && delim != '[' {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return s.syntaxError(err)
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// readDelim 读取对象或数组的结束符
+func (s *jsonStream) readDelim(delim json.Delim) error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return s.syntaxError(err)
+	}
+	if tok != delim {
+		return &DecodeError{MediaType: s.format.MediaType, Err: fmt.Errorf("expected %v, got %v", delim, tok)}
+	}
+	return nil
+}
+
+// syntaxError 包装读取 token 时的错误，请求体提前结束时返回 io.ErrUnexpectedEOF
+func (s *jsonStream) syntaxError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return &DecodeError{MediaType: s.format.MediaType, Err: err}
+}
+
+// typeError JSON 值的类型与字段类型不匹配
+func (s *jsonStream) typeError(f *fieldPlan, tok json.Token, t reflect.Type) error {
+	return s.fieldError(f, fmt.Sprint(tok), fmt.Errorf("cannot unmarshal %s into %v", jsonTokenKind(tok), t))
+}
+
+// fieldError 字段的值无法转换时返回 FieldConversionError，顶层值返回 DecodeError
+func (s *jsonStream) fieldError(f *fieldPlan, value string, err error) error {
+	if f == nil {
+		return &DecodeError{MediaType: s.format.MediaType, Err: err}
+	}
+	conversionErr := f.conversionError(s.fieldPath(f).path, SourceBody, value, err)
+	conversionErr.Name = s.pathString()
+	return conversionErr
+}
+
+// jsonTokenKind 返回 token 对应的 JSON 类型名
+func jsonTokenKind(tok json.Token) string {
+	switch tok.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	case nil:
+		return "null"
+	}
+	if tok == json.Delim('[') {
+		return "array"
+	}
+	return "object"
+}
+
+// isBasicKind 字符串、数字与布尔类型
+func isBasicKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
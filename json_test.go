@@ -0,0 +1,474 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestJSONStreamDecoding 测试流式 JSON 解码的各类字段
+func TestJSONStreamDecoding(t *testing.T) {
+	type Item struct {
+		Name string    `json:"name"`
+		At   time.Time `json:"at"`
+	}
+	type Embedded struct {
+		TraceId string `json:"traceId" header:"traceId"`
+	}
+	type testStruct struct {
+		Embedded
+		ID       int64                `json:"id"`
+		Count    uint8                `json:"count"`
+		Ratio    float32              `json:"ratio"`
+		Name     string               `json:"name"`
+		Nick     *string              `json:"nick"`
+		Items    []Item               `json:"items"`
+		Ptrs     []*Item              `json:"ptrs"`
+		Dates    []time.Time          `json:"dates"`
+		Pair     [2]int               `json:"pair"`
+		Events   map[string]time.Time `json:"events"`
+		Extra    map[string]any       `json:"extra"`
+		Raw      json.RawMessage      `json:"raw"`
+		Ignored  string               `json:"-"`
+		NoTag    string
+		internal string
+	}
+
+	body := `{
+		"traceId": "t-1",
+		"id": 9007199254740993,
+		"count": 7,
+		"ratio": 0.5,
+		"NAME": "case-insensitive",
+		"nick": null,
+		"items": [{"name": "a", "at": "2024-01-02 03:04:05"}, {"name": "b", "at": 1700000000}],
+		"ptrs": [null, {"name": "c"}],
+		"dates": ["2024-01-02", 1700000000123],
+		"pair": [1, 2, 3],
+		"events": {"open": "2024-05-06"},
+		"extra": {"nested": [1, {"x": true}], "big": 12345678901234567890},
+		"raw": {"keep": [1, 2]},
+		"-": "ignored",
+		"Ignored": "ignored",
+		"notag": "promoted by name",
+		"internal": "skipped",
+		"unknown": {"deep": [{"a": 1}]}
+	}`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("traceId", "header")
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 请求体中的值优先于 header，嵌入结构体的字段同样被记录为已设置
+	if result.TraceId != "t-1" {
+		t.Errorf("expected promoted TraceId from body, got %q", result.TraceId)
+	}
+	if result.ID != 9007199254740993 || result.Count != 7 || result.Ratio != 0.5 || result.Name != "case-insensitive" || result.Nick != nil {
+		t.Errorf("unexpected scalars: %+v", result)
+	}
+	if len(result.Items) != 2 || result.Items[0].At.Hour() != 3 || result.Items[1].At.Unix() != 1700000000 {
+		t.Errorf("unexpected Items: %+v", result.Items)
+	}
+	if len(result.Ptrs) != 2 || result.Ptrs[0] != nil || result.Ptrs[1].Name != "c" {
+		t.Errorf("unexpected Ptrs: %+v", result.Ptrs)
+	}
+	if len(result.Dates) != 2 || result.Dates[0].Day() != 2 || result.Dates[1].UnixMilli() != 1700000000123 {
+		t.Errorf("unexpected Dates: %v", result.Dates)
+	}
+	if result.Pair != [2]int{1, 2} || result.Events["open"].Month() != time.May {
+		t.Errorf("unexpected Pair/Events: %v %v", result.Pair, result.Events)
+	}
+	if !reflect.DeepEqual(result.Extra, map[string]any{"nested": []any{json.Number("1"), map[string]any{"x": true}}, "big": json.Number("12345678901234567890")}) {
+		t.Errorf("unexpected Extra: %#v", result.Extra)
+	}
+	if string(result.Raw) != `{"keep": [1, 2]}` {
+		t.Errorf("unexpected Raw: %s", result.Raw)
+	}
+	if result.Ignored != "" || result.NoTag != "promoted by name" || result.internal != "" {
+		t.Errorf("unexpected tag handling: %+v", result)
+	}
+}
+
+// TestJSONStreamErrors 测试流式 JSON 解码的类型错误与格式错误
+func TestJSONStreamErrors(t *testing.T) {
+	type Item struct {
+		Qty int `json:"qty"`
+	}
+	type testStruct struct {
+		Age   int    `json:"age"`
+		Small int8   `json:"small"`
+		Name  string `json:"name"`
+		Items []Item `json:"items"`
+		Meta  Item   `json:"meta"`
+	}
+
+	conversionTests := []struct {
+		body string
+		name string
+	}{
+		{`{"age":"ten"}`, "age"},
+		{`{"age":1.5}`, "age"},
+		{`{"small":300}`, "small"},
+		{`{"name":{"first":"a"}}`, "name"},
+		{`{"items":[{"qty":1},{"qty":"x"}]}`, "items[1].qty"},
+		{`{"meta":[1]}`, "meta"},
+	}
+	for _, tt := range conversionTests {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+		_, _, err := Valid[testStruct](req)
+		var conversionErr *FieldConversionError
+		if !errors.As(err, &conversionErr) || conversionErr.Name != tt.name || conversionErr.Source != SourceBody {
+			t.Errorf("%s: expected *FieldConversionError for %s, got %T: %v", tt.body, tt.name, err, err)
+		}
+	}
+
+	for _, body := range []string{`{"age":1}{}`, `{"age":1,}`, `[1]`, `{"age":1`} {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+		_, _, err := Valid[testStruct](req)
+		var decodeErr *DecodeError
+		var conversionErr *FieldConversionError
+		if !errors.As(err, &decodeErr) && !errors.As(err, &conversionErr) {
+			t.Errorf("%s: expected a binding error, got %T: %v", body, err, err)
+		}
+	}
+}
+
+type strictOrder struct {
+	Name  string `json:"name"`
+	Items []struct {
+		Qty int `json:"qty"`
+	} `json:"items"`
+	Tags  map[string]string `json:"tags"`
+	Extra json.RawMessage   `json:"extra"`
+}
+
+func (strictOrder) StrictJSON() bool { return true }
+
+type lenientOrder struct {
+	Name string `json:"name"`
+}
+
+func (lenientOrder) StrictJSON() bool { return false }
+
+// TestStrictJSON 测试严格模式下报告未知字段与重复的键
+func TestStrictJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		unknown   []string
+		duplicate []string
+	}{
+		{"unknown", `{"name":"a","nmae":"b","items":[{"qty":1},{"qyt":2}]}`, []string{"nmae", "items[1].qyt"}, nil},
+		{"duplicate", `{"name":"a","Name":"b","tags":{"k":"1","k":"2"}}`, nil, []string{"Name", "tags.k"}},
+		{"both", `{"x":1,"x":2}`, []string{"x"}, []string{"x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			_, _, err := Valid[strictOrder](req)
+			var strictErr *StrictJSONError
+			if !errors.As(err, &strictErr) {
+				t.Fatalf("expected *StrictJSONError, got %T: %v", err, err)
+			}
+			if !reflect.DeepEqual(strictErr.UnknownFields, tt.unknown) || !reflect.DeepEqual(strictErr.DuplicateFields, tt.duplicate) {
+				t.Errorf("unexpected error: %+v", strictErr)
+			}
+			if StatusCode(err) != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", StatusCode(err))
+			}
+		})
+	}
+
+	// json.RawMessage 保留原始内容，不检查其中的键
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a","extra":{"any":1}}`))
+	if _, _, err := Valid[strictOrder](req); err != nil {
+		t.Errorf("expected RawMessage to accept any object, got %v", err)
+	}
+
+	// 请求体末尾多余的数据始终返回 DecodeError
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a"} garbage`))
+	var decodeErr *DecodeError
+	if _, _, err := Valid[strictOrder](req); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError for trailing data, got %T: %v", err, err)
+	}
+}
+
+// TestWithStrictJSON 测试 Binder 级别的严格模式，请求类型可以单独关闭
+func TestWithStrictJSON(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+	}
+	b := NewBinder(WithStrictJSON())
+	body := `{"name":"a","unknown":true}`
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	var strictErr *StrictJSONError
+	if _, _, err := Bind[testStruct](b, req); !errors.As(err, &strictErr) || strictErr.UnknownFields[0] != "unknown" {
+		t.Errorf("expected *StrictJSONError, got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	if result, _, err := Bind[lenientOrder](b, req); err != nil || result.Name != "a" {
+		t.Errorf("expected lenient type to ignore unknown fields, got %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	if _, _, err := Valid[testStruct](req); err != nil {
+		t.Errorf("expected default binder to ignore unknown fields, got %v", err)
+	}
+}
+
+// TestTopLevelJSON 测试请求体为数组、对象或基础类型的 JSON
+func TestTopLevelJSON(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" v:"required"`
+		Qty  int    `json:"qty" v:"gte=1"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"name":"a","qty":1},{"name":"b","qty":2}]`))
+	items, _, err := Valid[[]Item](req)
+	if err != nil || len(items) != 2 || items[1].Name != "b" {
+		t.Fatalf("unexpected result: %+v, %v", items, err)
+	}
+
+	// 每个元素按 dive 校验，错误使用请求体中的路径
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"name":"a","qty":1},{"qty":0}]`))
+	_, parserResult, err := Valid[[]Item](req)
+	var validationErr *ValidationError
+	if parserResult != ParserResultNotVerified || !errors.As(err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("expected 2 validation errors, got %v, %v", parserResult, err)
+	}
+	if fe := validationErr.Errors[0]; fe.Name != "[1].name" || fe.Field != "[1].Name" || fe.Source != SourceBody || fe.Rule != "required" {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"a":{"name":"x","qty":1},"b":{"name":"y"}}`))
+	_, _, err = Valid[map[string]Item](req)
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Name != "[b].qty" {
+		t.Errorf("expected validation error for [b].qty, got %v", err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"qty":"x"}]`))
+	_, _, err = Valid[[]Item](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "[0].qty" {
+		t.Errorf("expected *FieldConversionError for [0].qty, got %T: %v", err, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`"hello"`))
+	if str, _, err := Valid[string](req); err != nil || str != "hello" {
+		t.Errorf("unexpected string body: %q, %v", str, err)
+	}
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`42`))
+	if n, _, err := Valid[int64](req); err != nil || n != 42 {
+		t.Errorf("unexpected number body: %d, %v", n, err)
+	}
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"a":1}`))
+	var decodeErr *DecodeError
+	if _, _, err := Valid[[]Item](req); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError for object body, got %T: %v", err, err)
+	}
+}
+
+// TestPointerResult 测试 T 为指针时校验其指向的值，错误与非指针的 T 一致
+func TestPointerResult(t *testing.T) {
+	type testStruct struct {
+		TraceId string `header:"traceId" v:"required"`
+		Name    string `json:"name" v:"required"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":""}`))
+	req.Header.Set("traceId", "t-1")
+	result, parserResult, err := Valid[*testStruct](req)
+	var validationErr *ValidationError
+	if parserResult != ParserResultNotVerified || !errors.As(err, &validationErr) || len(validationErr.Errors) != 1 {
+		t.Fatalf("expected a validation error, got %v, %v", parserResult, err)
+	}
+	if fe := validationErr.Errors[0]; fe.Field != "Name" || fe.Name != "name" || fe.Source != SourceBody {
+		t.Errorf("unexpected field error: %+v", fe)
+	}
+	if result == nil || result.TraceId != "t-1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	type Item struct {
+		Name string `json:"name" v:"required"`
+	}
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"name":"a"},{}]`))
+	_, _, err = Valid[*[]Item](req)
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 1 || validationErr.Errors[0].Name != "[1].name" {
+		t.Errorf("expected a validation error for [1].name, got %v", err)
+	}
+}
+
+// TestBodyTag 测试 body 标签将整个请求体绑定到一个字段，其余字段来自 header 与 query
+func TestBodyTag(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" v:"required"`
+		Qty  int    `json:"qty"`
+	}
+	type testStruct struct {
+		TraceId string `header:"traceId" v:"required"`
+		DryRun  bool   `param:"dryRun"`
+		Items   []Item `body:"" v:"required,dive"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test?dryRun=true", bytes.NewBufferString(`[{"name":"a","qty":1},{"name":"b"}]`))
+	req.Header.Set("traceId", "t-1")
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TraceId != "t-1" || !result.DryRun || len(result.Items) != 2 || result.Items[1].Name != "b" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"qty":1}]`))
+	req.Header.Set("traceId", "t-1")
+	_, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Name != "[0].name" || validationErr.Errors[0].Field != "Items[0].Name" {
+		t.Errorf("expected validation error for [0].name, got %v", err)
+	}
+
+	// 请求体为空时报告整个请求体缺失
+	req, _ = http.NewRequest("POST", "/test", nil)
+	req.Header.Set("traceId", "t-1")
+	_, _, err = Valid[testStruct](req)
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != "body is required" {
+		t.Errorf("expected \"body is required\", got %v", err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`[{"qty":"x"}]`))
+	_, _, err = Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Field != "Items.Qty" || conversionErr.Name != "[0].qty" {
+		t.Errorf("expected *FieldConversionError for Items.Qty, got %T: %v", err, err)
+	}
+}
+
+type embeddedInt int
+
+type embeddedInner struct {
+	Name string `json:"name"`
+}
+
+// TestUnexportedEmbeddedJSON 测试未导出的嵌入字段与 encoding/json 一致被忽略，不会在赋值时 panic
+func TestUnexportedEmbeddedJSON(t *testing.T) {
+	type intStruct struct {
+		embeddedInt
+		Name string `json:"name"`
+	}
+	type ptrStruct struct {
+		*embeddedInner
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"embeddedInt":1,"name":"a"}`))
+	result, _, err := Valid[intStruct](req)
+	if err != nil || result.embeddedInt != 0 || result.Name != "a" {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"embeddedInner":{},"name":"b"}`))
+	ptrResult, _, err := Valid[ptrStruct](req)
+	if err != nil || ptrResult.embeddedInner != nil {
+		t.Errorf("unexpected result: %+v, %v", ptrResult, err)
+	}
+}
+
+type benchItem struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Price     float64   `json:"price"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"createdAt"`
+	Active    bool      `json:"active"`
+}
+
+type benchLargeReq struct {
+	Items []benchItem `json:"items"`
+	Total int         `json:"total"`
+}
+
+func newLargeJSONBody(n int) []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"items":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":%d,"name":"item-%d","price":%d.99,"tags":["a","b"],"createdAt":"2024-06-01T08:00:00Z","active":true}`, i, i, i)
+	}
+	fmt.Fprintf(&sb, `],"total":%d}`, n)
+	return []byte(sb.String())
+}
+
+func benchmarkLargeJSON(b *testing.B, n int) {
+	body := newLargeJSONBody(n)
+	binder := NewBinder()
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if _, _, err := Bind[benchLargeReq](binder, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBindJSON100Items(b *testing.B)   { benchmarkLargeJSON(b, 100) }
+func BenchmarkBindJSON10000Items(b *testing.B) { benchmarkLargeJSON(b, 10000) }
+
+// BenchmarkStdlibJSON10000Items 作为对照，仅使用 encoding/json 解码同样的请求体
+func BenchmarkStdlibJSON10000Items(b *testing.B) {
+	body := newLargeJSONBody(10000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var result benchLargeReq
+		if err := json.Unmarshal(body, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestJSONStringOption 测试 json:",string" 字段按 encoding/json 的规则从字符串中取值
+func TestJSONStringOption(t *testing.T) {
+	type testStruct struct {
+		Count   int64    `json:"count,string"`
+		Ratio   *float64 `json:"ratio,string"`
+		Enabled bool     `json:"enabled,string"`
+		Label   string   `json:"label,string"`
+		IDs     []int    `json:"ids,string"` // 切片不受 ,string 影响
+	}
+	body := `{"count":"123","ratio":"0.5","enabled":"true","label":"\"a\"","ids":[1,2]}`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Count != 123 || result.Ratio == nil || *result.Ratio != 0.5 || !result.Enabled || result.Label != "a" || len(result.IDs) != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"ratio":"null"}`))
+	if result, _, err = Valid[testStruct](req); err != nil || result.Ratio != nil {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+
+	for _, body := range []string{`{"count":123}`, `{"count":"12x"}`, `{"count":"[1]"}`, `{"count":"1 2"}`} {
+		req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+		_, _, err := Valid[testStruct](req)
+		var conversionErr *FieldConversionError
+		if !errors.As(err, &conversionErr) || conversionErr.Name != "count" {
+			t.Errorf("%s: expected *FieldConversionError for count, got %T: %v", body, err, err)
+		}
+	}
+}
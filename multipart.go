@@ -0,0 +1,199 @@
+package chttp
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+const (
+	multipartMediaType = "multipart/form-data"
+
+	// defaultMultipartMaxMemory 与 net/http 一致，超过部分写入临时文件
+	defaultMultipartMaxMemory = 32 << 20
+)
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+	multipartFileType   = reflect.TypeOf((*multipart.File)(nil)).Elem()
+)
+
+// WithMultipartMaxMemory 设置解析 multipart/form-data 时保存在内存中的最大字节数，超过部分写入临时文件
+func WithMultipartMaxMemory(n int64) Option {
+	return func(b *Binder) {
+		b.multipartMaxMemory = n
+	}
+}
+
+// parseMultipart 解析 multipart/form-data 请求体，文本字段写入 r.PostForm，文件保存在 r.MultipartForm.File
+func (b *Binder) parseMultipart(r *http.Request, limit int64) error {
+	if limit > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, limit)
+	}
+	if err := r.ParseMultipartForm(b.multipartMaxMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return &BodyTooLargeError{Limit: maxBytesErr.Limit}
+		}
+		return &DecodeError{MediaType: multipartMediaType, Err: err}
+	}
+	return nil
+}
+
+// isFileField 字段是否为可绑定上传文件的类型
+func isFileField(t reflect.Type) bool {
+	return t == fileHeaderType || t == fileHeaderSliceType || t == multipartFileType
+}
+
+// setFileField 将上传的文件绑定到字段，multipart.File 类型的字段由调用方负责关闭
+func setFileField(field reflect.Value, files []*multipart.FileHeader) error {
+	switch field.Type() {
+	case fileHeaderType:
+		field.Set(reflect.ValueOf(files[0]))
+	case fileHeaderSliceType:
+		field.Set(reflect.ValueOf(files))
+	case multipartFileType:
+		file, err := files[0].Open()
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&file).Elem())
+	}
+	return nil
+}
+
+// RegisterFileValidations 注册上传文件的校验规则：
+// filesize=10MB 限制单个文件大小（支持 B/KB/MB/GB），
+// filetype=image/png image/* 按文件内容检测 MIME 类型。
+// Binder 自带的校验器已经注册，使用 WithValidator 时可自行调用
+func RegisterFileValidations(v *validator.Validate) error {
+	if err := v.RegisterValidation("filesize", validateFileSize); err != nil {
+		return err
+	}
+	return v.RegisterValidation("filetype", validateFileType)
+}
+
+func validateFileSize(fl validator.FieldLevel) bool {
+	limit, err := parseByteSize(fl.Param())
+	if err != nil {
+		// Binder 在绑定前已经通过 checkTags 报告无法解析的参数，这里只会在单独使用校验器时出现
+		return false
+	}
+	switch file := fl.Field().Interface().(type) {
+	case nil:
+		return true
+	case *multipart.FileHeader:
+		return file == nil || file.Size <= limit
+	case multipart.FileHeader:
+		return file.Size <= limit
+	case []*multipart.FileHeader:
+		for _, fh := range file {
+			if fh != nil && fh.Size > limit {
+				return false
+			}
+		}
+		return true
+	case multipart.File:
+		size, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return false
+		}
+		_, err = file.Seek(0, io.SeekStart)
+		return err == nil && size <= limit
+	}
+	return false
+}
+
+func validateFileType(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+	matches := func(content io.Reader) bool {
+		detected, err := mimetype.DetectReader(content)
+		if err != nil {
+			return false
+		}
+		for _, t := range allowed {
+			if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+				if strings.HasPrefix(detected.String(), prefix+"/") {
+					return true
+				}
+			} else if detected.Is(t) {
+				return true
+			}
+		}
+		return false
+	}
+	switch file := fl.Field().Interface().(type) {
+	case nil:
+		return true
+	case *multipart.FileHeader:
+		return file == nil || fileHeaderMatches(file, matches)
+	case multipart.FileHeader:
+		return fileHeaderMatches(&file, matches)
+	case []*multipart.FileHeader:
+		for _, fh := range file {
+			if fh != nil && !fileHeaderMatches(fh, matches) {
+				return false
+			}
+		}
+		return true
+	case multipart.File:
+		ok := matches(file)
+		_, err := file.Seek(0, io.SeekStart)
+		return ok && err == nil
+	}
+	return false
+}
+
+// fileHeaderMatches 打开上传的文件并检查其内容
+func fileHeaderMatches(fh *multipart.FileHeader, matches func(content io.Reader) bool) bool {
+	file, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	return matches(file)
+}
+
+// checkFileSizeRules 检查校验标签中 filesize 规则的参数能否解析
+func checkFileSizeRules(tag string) error {
+	for _, rule := range strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == '|' }) {
+		if param, ok := strings.CutPrefix(rule, "filesize="); ok {
+			if _, err := parseByteSize(param); err != nil {
+				return errors.Wrapf(err, "invalid filesize param %q", param)
+			}
+		}
+	}
+	return nil
+}
+
+// parseByteSize 解析 "1024"、"512KB"、"10MB"、"1GB" 等大小
+func parseByteSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	for _, unit := range units {
+		if number, ok := strings.CutSuffix(upper, unit.suffix); ok {
+			n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return n * unit.size, nil
+		}
+	}
+	return strconv.ParseInt(upper, 10, 64)
+}
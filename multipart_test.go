@@ -0,0 +1,186 @@
+package chttp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+)
+
+var pngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89")
+
+type multipartFile struct {
+	field   string
+	name    string
+	content []byte
+}
+
+func newMultipartRequest(target string, fields map[string]string, files ...multipartFile) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for k, v := range fields {
+		_ = writer.WriteField(k, v)
+	}
+	for _, f := range files {
+		part, _ := writer.CreateFormFile(f.field, f.name)
+		_, _ = part.Write(f.content)
+	}
+	_ = writer.Close()
+	req, _ := http.NewRequest("POST", target, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestMultipartBinding 测试 multipart/form-data 文本字段与文件绑定
+func TestMultipartBinding(t *testing.T) {
+	type testStruct struct {
+		Title       string                  `form:"title" v:"required"`
+		Count       *int                    `form:"count"`
+		Public      bool                    `form:"public" default:"true"`
+		Page        int                     `param:"page"`
+		Avatar      *multipart.FileHeader   `form:"avatar" v:"required"`
+		Attachments []*multipart.FileHeader `form:"attachments"`
+		Raw         multipart.File          `form:"raw"`
+		Missing     *multipart.FileHeader   `form:"missing"`
+	}
+
+	req := newMultipartRequest("/upload?page=3",
+		map[string]string{"title": "hello", "count": "2", "public": "false"},
+		multipartFile{"avatar", "a.png", pngContent},
+		multipartFile{"attachments", "1.txt", []byte("one")},
+		multipartFile{"attachments", "2.txt", []byte("two")},
+		multipartFile{"raw", "raw.bin", []byte("raw content")},
+	)
+	result, parserResult, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	if result.Title != "hello" || result.Count == nil || *result.Count != 2 || result.Public || result.Page != 3 {
+		t.Errorf("unexpected text fields: %+v", result)
+	}
+	if result.Avatar == nil || result.Avatar.Filename != "a.png" || result.Avatar.Size != int64(len(pngContent)) {
+		t.Errorf("unexpected avatar: %+v", result.Avatar)
+	}
+	if len(result.Attachments) != 2 || result.Attachments[1].Filename != "2.txt" {
+		t.Errorf("unexpected attachments: %+v", result.Attachments)
+	}
+	if result.Missing != nil {
+		t.Errorf("expected Missing to be nil, got %+v", result.Missing)
+	}
+	if result.Raw == nil {
+		t.Fatal("expected Raw to be opened")
+	}
+	defer result.Raw.Close()
+	content, _ := io.ReadAll(result.Raw)
+	if string(content) != "raw content" {
+		t.Errorf("unexpected raw content: %q", content)
+	}
+}
+
+// TestMultipartFileValidation 测试 filesize 与 filetype 校验规则
+func TestMultipartFileValidation(t *testing.T) {
+	type testStruct struct {
+		Avatar *multipart.FileHeader   `form:"avatar" v:"required,filesize=1KB,filetype=image/png image/jpeg"`
+		Docs   []*multipart.FileHeader `form:"docs" v:"omitempty,filesize=8,filetype=text/*"`
+	}
+
+	req := newMultipartRequest("/upload", nil,
+		multipartFile{"avatar", "a.png", pngContent},
+		multipartFile{"docs", "a.txt", []byte("short")},
+	)
+	if _, parserResult, err := Valid[testStruct](req); parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+
+	tests := []struct {
+		name     string
+		files    []multipartFile
+		expected string
+	}{
+		{"missing", nil, "form avatar is required"},
+		{"too_large", []multipartFile{{"avatar", "a.png", append(pngContent, make([]byte, 1024)...)}}, "form avatar must not exceed 1KB"},
+		{"wrong_type", []multipartFile{{"avatar", "a.png", []byte("not an image")}}, "form avatar must be a file of type [image/png image/jpeg]"},
+		{"doc_too_large", []multipartFile{{"avatar", "a.png", pngContent}, {"docs", "b.txt", []byte("longer than eight")}}, "form docs must not exceed 8"},
+		{"doc_wrong_type", []multipartFile{{"avatar", "a.png", pngContent}, {"docs", "b.png", pngContent[:8]}}, "form docs must be a file of type [text/*]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newMultipartRequest("/upload", nil, tt.files...)
+			_, parserResult, err := Valid[testStruct](req)
+			if parserResult != ParserResultNotVerified {
+				t.Fatalf("expected ParserResultNotVerified, got %v: %v", parserResult, err)
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != tt.expected {
+				t.Errorf("expected %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestInvalidFileSizeTag 测试无法解析的 filesize 参数返回 TagError 而不是在校验时 panic
+func TestInvalidFileSizeTag(t *testing.T) {
+	type testStruct struct {
+		Avatar *multipart.FileHeader `form:"avatar" v:"omitempty,filesize=10XB"`
+	}
+
+	req := newMultipartRequest("/upload", nil, multipartFile{"avatar", "a.png", pngContent})
+	_, _, err := Valid[testStruct](req)
+	var tagErr *TagError
+	if !errors.As(err, &tagErr) || tagErr.Field != "Avatar" || tagErr.Tag != "v" || tagErr.Value != "omitempty,filesize=10XB" {
+		t.Fatalf("expected *TagError for Avatar, got %T: %v", err, err)
+	}
+	if StatusCode(err) != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", StatusCode(err))
+	}
+}
+
+// TestMultipartLimits 测试 multipart 请求体大小限制与格式错误
+func TestMultipartLimits(t *testing.T) {
+	type testStruct struct {
+		File *multipart.FileHeader `form:"file"`
+	}
+
+	b := NewBinder(WithMaxBodySize(256), WithMultipartMaxMemory(64))
+	req := newMultipartRequest("/upload", nil, multipartFile{"file", "big.bin", make([]byte, 1024)})
+	_, _, err := Bind[testStruct](b, req)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 256 {
+		t.Errorf("expected *BodyTooLargeError, got %T: %v", err, err)
+	}
+
+	// 超过 maxMemory 的文件写入临时文件，仍然可以绑定
+	b = NewBinder(WithMultipartMaxMemory(64))
+	req = newMultipartRequest("/upload", nil, multipartFile{"file", "big.bin", make([]byte, 1024)})
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil || result.File == nil || result.File.Size != 1024 {
+		t.Errorf("expected file to be bound, got %+v, %v", result.File, err)
+	}
+	_ = req.MultipartForm.RemoveAll()
+
+	req, _ = http.NewRequest("POST", "/upload", bytes.NewBufferString("garbage"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+	_, _, err = Valid[testStruct](req)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "multipart/form-data" {
+		t.Errorf("expected *DecodeError, got %T: %v", err, err)
+	}
+}
+
+// TestParseByteSize 测试文件大小参数解析
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{"1024": 1024, "8B": 8, "2KB": 2048, "10mb": 10 << 20, "1 GB": 1 << 30}
+	for input, expected := range tests {
+		if got, err := parseByteSize(input); err != nil || got != expected {
+			t.Errorf("parseByteSize(%q) = %d, %v; expected %d", input, got, err, expected)
+		}
+	}
+	if _, err := parseByteSize("ten"); err == nil {
+		t.Error("expected error for invalid size")
+	}
+}
@@ -0,0 +1,514 @@
+package chttp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	cookieType    = reflect.TypeOf(http.Cookie{})
+	cookiePtrType = reflect.TypeOf((*http.Cookie)(nil))
+)
+
+// typePlan 某个结构体类型预先编译好的解析计划，同一个类型只编译一次
+type typePlan struct {
+	fields []*fieldPlan
+	body   *fieldPlan // 带 body 标签、接收整个请求体的字段
+	err    *TagError  // 第一个无法解析的标签，对应字段使用默认规则
+
+	checkOnce sync.Once
+	checkErr  error // 该类型及其嵌套结构体中第一个无法解析的标签，见 checkTags
+
+	keyIndexes sync.Map // 标签名 -> 键到字段链的索引，嵌入结构体的字段会提升到外层，见 keyFields
+
+	xmlOnce  sync.Once
+	xmlIndex *xmlIndex // 见 xmlFields
+}
+
+// fieldPlan 单个字段预先解析好的标签、路径和赋值函数
+type fieldPlan struct {
+	index        int
+	name         string // Go 字段名，完整的字段路径在解析时按层拼接，见 parseRequestParamsWithPlan
+	typ          reflect.Type
+	settable     bool
+	anonymous    bool
+	jsonName     string
+	hasJSONTag   bool
+	jsonSkip     bool // json:"-"
+	jsonInline   bool // 没有 json 标签的嵌入结构体，其字段在 JSON 中提升到外层
+	jsonString   bool // json:",string"，基础类型的值以 JSON 字符串的形式传递
+	body         bool // body 标签，整个请求体绑定到该字段
+	tag          reflect.StructTag
+	query        string
+	form         string
+	header       string
+	cookie       string
+	ctx          string
+	url          string
+	recurse      bool
+	file         bool // 上传文件字段，见 isFileField
+	wholeCookie  bool // http.Cookie 或 *http.Cookie 字段，绑定整个 Cookie
+	defaultValue string
+	rawJSONIndex int         // rawJson 标签指向的字段下标，-1 表示没有
+	timeFormat   *timeFormat // time.Time 及其指针、切片字段的解析规则
+	set          func(field reflect.Value, value string) error
+
+	// 切片字段从多个原始值赋值，sep 为各来源拆分单个值使用的分隔符，空字符串表示不拆分
+	multi    bool
+	sep      map[Source]string
+	setSlice func(field reflect.Value, values []string) error
+
+	// deepObject 风格的 query 参数（filter[status]=open）绑定到 map 或嵌套结构体，setElem 为 map 元素赋值
+	deepObject bool
+	setElem    func(field reflect.Value, values []string) error
+
+	binder     *Binder
+	nestedOnce sync.Once
+	nestedPlan *typePlan
+}
+
+// maxNestingDepth 请求体中嵌套结构体的最大层数，自引用类型超过时返回 DecodeError
+const maxNestingDepth = 1000
+
+// nested 返回嵌套结构体（或结构体指针、结构体切片等）的解析计划，首次使用时才查找，避免自引用类型无限递归。
+// 同一类型共用 planFor 缓存的计划，只有 deepObject 字段需要带父级前缀的 query 参数名，单独编译一份
+func (f *fieldPlan) nested() *typePlan {
+	f.nestedOnce.Do(func() {
+		t := f.typ
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if !f.deepObject {
+			f.nestedPlan = f.binder.planFor(t)
+			return
+		}
+		f.nestedPlan = f.binder.compilePlan(t)
+		// 嵌套结构体的 query 参数名带上父级前缀，如 filter[status]
+		for _, child := range f.nestedPlan.fields {
+			if child.query != "" {
+				child.query = f.query + "[" + child.query + "]"
+			}
+		}
+	})
+	return f.nestedPlan
+}
+
+// field 按 Go 字段名查找字段
+func (p *typePlan) field(name string) *fieldPlan {
+	for _, f := range p.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// resolve 根据校验器给出的字段路径（如 "Items[0].Name"）找到对应的字段，同时返回不带下标的字段路径（如 "Items.Name"），
+// 以及该字段在请求体中按 keyTag 标签命名的路径（如 "items[0].name"），嵌入结构体不计入请求体路径
+func (p *typePlan) resolve(path, keyTag string) (*fieldPlan, string, string) {
+	plan := p
+	var field *fieldPlan
+	var fieldPath, bodyPath []string
+	for _, segment := range strings.Split(path, ".") {
+		name, index := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+		field = plan.field(name)
+		if field == nil {
+			return nil, "", ""
+		}
+		fieldPath = append(fieldPath, name)
+		switch {
+		case field.body:
+			// 整个请求体绑定到该字段，请求体路径只保留下标，如 "[0].name"
+			if index != "" {
+				bodyPath = append(bodyPath, index)
+			}
+		default:
+			if key, promoted := field.bodyKey(keyTag); !promoted || index != "" {
+				bodyPath = append(bodyPath, key+index)
+			}
+		}
+		plan = field.nested()
+	}
+	return field, strings.Join(fieldPath, "."), strings.Join(bodyPath, ".")
+}
+
+// bodyKey 返回字段在按 keyTag 标签命名的请求体中的键名，promoted 表示没有命名的嵌入结构体，其字段提升到外层。
+// xml 与 encoding/xml 一致，没有 xml 标签时使用 Go 字段名，其余标签没有名称时沿用 json 标签的名称
+func (f *fieldPlan) bodyKey(keyTag string) (name string, promoted bool) {
+	if keyTag == "" || keyTag == "json" {
+		return f.jsonName, f.anonymous && !f.hasJSONTag
+	}
+	value, ok := f.tag.Lookup(keyTag)
+	name, opts, _ := strings.Cut(value, ",")
+	switch {
+	case name == "" && hasTagOption(opts, "inline"):
+		return "", true
+	case name != "" && name != "-":
+		return name, false
+	case keyTag == "xml":
+		return f.name, f.anonymous && !ok
+	}
+	return f.jsonName, f.anonymous && !f.hasJSONTag
+}
+
+// hasBodyKey 字段是否在按 keyTag 标签命名的请求体中声明了键名
+func (f *fieldPlan) hasBodyKey(keyTag string) bool {
+	if f.hasJSONTag {
+		return true
+	}
+	if keyTag == "" || keyTag == "json" {
+		return false
+	}
+	_, ok := f.tag.Lookup(keyTag)
+	return ok
+}
+
+// planFor 返回类型 t 的解析计划，并发安全
+func (b *Binder) planFor(t reflect.Type) *typePlan {
+	if p, ok := b.plans.Load(t); ok {
+		return p.(*typePlan)
+	}
+	p, _ := b.plans.LoadOrStore(t, b.compilePlan(t))
+	return p.(*typePlan)
+}
+
+// compilePlan 解析结构体的所有字段标签
+func (b *Binder) compilePlan(t reflect.Type) *typePlan {
+	plan := &typePlan{}
+	if t.Kind() != reflect.Struct {
+		return plan
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		f := &fieldPlan{
+			index:        i,
+			name:         sf.Name,
+			typ:          sf.Type,
+			settable:     sf.IsExported(),
+			anonymous:    sf.Anonymous,
+			jsonName:     sf.Name,
+			ctx:          sf.Tag.Get(b.tags.Context),
+			defaultValue: sf.Tag.Get(b.tags.Default),
+			rawJSONIndex: -1,
+			binder:       b,
+		}
+		// query 与表单默认使用重复的键传递多个值，header、路径参数与 Cookie 默认以逗号分隔
+		f.sep = make(map[Source]string)
+		f.query, f.sep[SourceQuery] = parseParamTag(sf.Tag.Get(b.tags.Query), true)
+		f.form, f.sep[SourceForm] = parseParamTag(sf.Tag.Get(b.tags.Form), true)
+		f.header, f.sep[SourceHeader] = parseParamTag(sf.Tag.Get(b.tags.Header), false)
+		f.url, f.sep[SourcePath] = parseParamTag(sf.Tag.Get(b.tags.URL), false)
+		f.cookie, f.sep[SourceCookie] = parseParamTag(sf.Tag.Get(b.tags.Cookie), false)
+		if f.form == "" && b.formParam {
+			f.form, f.sep[SourceForm] = f.query, f.sep[SourceQuery]
+		}
+		// 处理 "fieldname,omitempty" 格式
+		if jsonTag := sf.Tag.Get("json"); jsonTag == "-" {
+			f.jsonSkip = true
+		} else if jsonTag != "" {
+			name, opts, _ := strings.Cut(jsonTag, ",")
+			if name != "" {
+				f.jsonName = name
+			}
+			f.hasJSONTag = true
+			f.jsonString = hasTagOption(opts, "string") && isQuotableType(sf.Type)
+		}
+		f.tag = sf.Tag
+		if _, ok := sf.Tag.Lookup(b.tags.Body); ok && f.settable {
+			f.body = true
+			if plan.body == nil {
+				plan.body = f
+			}
+		}
+		if sf.Anonymous && !f.hasJSONTag && !f.jsonSkip {
+			switch {
+			case sf.Type.Kind() == reflect.Struct:
+				f.jsonInline = true
+			case sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct:
+				// 未导出的嵌入指针无法分配
+				f.jsonInline = f.settable
+			}
+		}
+		if sf.Tag.Get(b.tags.Recurse) != "" {
+			switch {
+			case sf.Type.Kind() == reflect.Struct:
+				f.recurse = true
+			case sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct:
+				f.recurse = true
+			}
+		}
+		if rawJSONTag := sf.Tag.Get(b.tags.RawJSON); rawJSONTag != "" {
+			if source, ok := t.FieldByName(strings.Split(rawJSONTag, ",")[0]); ok && len(source.Index) == 1 {
+				f.rawJSONIndex = source.Index[0]
+			}
+		}
+		f.file = isFileField(sf.Type)
+		if validateTag := sf.Tag.Get(b.tags.Validate); validateTag != "" {
+			if err := checkFileSizeRules(validateTag); err != nil && plan.err == nil {
+				plan.err = &TagError{Type: t, Field: sf.Name, Tag: b.tags.Validate, Value: validateTag, Err: err}
+			}
+		}
+		f.wholeCookie = sf.Type == cookieType || sf.Type == cookiePtrType
+		f.timeFormat = b.defaultTime
+		if timeTag := sf.Tag.Get(b.tags.Time); timeTag != "" {
+			tf, err := parseTimeTag(timeTag, b.defaultTime)
+			if err != nil {
+				if plan.err == nil {
+					plan.err = &TagError{Type: t, Field: sf.Name, Tag: b.tags.Time, Value: timeTag, Err: err}
+				}
+			} else {
+				f.timeFormat = tf
+			}
+		}
+		f.set = b.compileSetter(sf.Type, f.timeFormat)
+		if isMultiValue(sf.Type) && !f.file && !b.isScalar(sf.Type) {
+			f.multi = true
+			f.setSlice = b.compileSliceSetter(sf.Type, f.timeFormat)
+		}
+		if f.query != "" && !f.recurse && isDeepObject(sf.Type) && !f.file && !f.wholeCookie && !b.isScalar(sf.Type) {
+			f.deepObject = true
+			if sf.Type.Kind() == reflect.Map {
+				f.setElem = b.compileElemSetter(sf.Type.Elem(), f.timeFormat)
+			}
+		}
+		plan.fields = append(plan.fields, f)
+	}
+	return plan
+}
+
+// checkTags 返回类型 t（或其元素类型）及其嵌套结构体中第一个无法解析的标签错误，结果按类型缓存。
+// 绑定前检查，避免请求已经写入部分字段后才发现类型定义有误
+func (b *Binder) checkTags(t reflect.Type) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	plan := b.planFor(t)
+	plan.checkOnce.Do(func() {
+		plan.checkErr = b.findTagError(t, make(map[reflect.Type]bool))
+	})
+	return plan.checkErr
+}
+
+// findTagError 递归查找标签错误，visiting 避免自引用类型无限递归
+func (b *Binder) findTagError(t reflect.Type, visiting map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	plan := b.planFor(t)
+	if plan.err != nil {
+		return plan.err
+	}
+	for _, f := range plan.fields {
+		if err := b.findTagError(f.typ, visiting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDeepObject 字段是否可以按 deepObject 风格绑定：键为字符串的 map，或结构体及其指针（time.Time 除外）
+func isDeepObject(t reflect.Type) bool {
+	if t.Kind() == reflect.Map {
+		return t.Key().Kind() == reflect.String
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// compileElemSetter 生成 map 元素的赋值函数，切片元素接收所有值，其余只使用第一个值
+func (b *Binder) compileElemSetter(t reflect.Type, tf *timeFormat) func(field reflect.Value, values []string) error {
+	if isMultiValue(t) {
+		return b.compileSliceSetter(t, tf)
+	}
+	set := b.compileSetter(t, tf)
+	return func(field reflect.Value, values []string) error {
+		return set(field, values[0])
+	}
+}
+
+// setValues 将来源中的一个或多个原始值写入字段，非切片字段只使用第一个值
+func (f *fieldPlan) setValues(field reflect.Value, source Source, values []string) error {
+	if !f.multi {
+		return f.set(field, values[0])
+	}
+	if sep := f.sep[source]; sep != "" {
+		values = splitValues(values, sep)
+	}
+	return f.setSlice(field, values)
+}
+
+// parseParamTag 解析形如 "ids,explode=false,sep=pipe" 的参数标签，返回参数名以及切片字段拆分单个值的分隔符。
+// explode=true 表示多个值通过重复的键传递，不拆分；sep 可以是 comma、pipe、space 或任意字符，默认为逗号
+func parseParamTag(tag string, explode bool) (string, string) {
+	parts := strings.Split(tag, ",")
+	sep := ","
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "explode":
+			explode = value != "false"
+		case "sep":
+			explode = false
+			switch value {
+			case "comma":
+				sep = ","
+			case "pipe":
+				sep = "|"
+			case "space":
+				sep = " "
+			default:
+				sep = value
+			}
+		}
+	}
+	if explode {
+		sep = ""
+	}
+	return parts[0], sep
+}
+
+// splitValues 按分隔符拆分每个原始值，去掉空白和空元素
+func splitValues(values []string, sep string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, sep) {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// isMultiValue 字段是否为可接收多个参数值的切片，[]byte 除外
+func isMultiValue(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// compileSliceSetter 生成把多个字符串逐个转换为切片元素的函数
+func (b *Binder) compileSliceSetter(t reflect.Type, tf *timeFormat) func(field reflect.Value, values []string) error {
+	elemSet := b.compileSetter(t.Elem(), tf)
+	return func(field reflect.Value, values []string) error {
+		slice := reflect.MakeSlice(t, len(values), len(values))
+		for i, value := range values {
+			if err := elemSet(slice.Index(i), value); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+}
+
+// compileSetter 按字段类型生成把字符串转换并写入字段的函数，时间按 tf 解析
+func (b *Binder) compileSetter(t reflect.Type, tf *timeFormat) func(field reflect.Value, value string) error {
+	if set := b.compileScalarSetter(t); set != nil {
+		return set
+	}
+	if isMultiValue(t) {
+		// 单个字符串（如 default 标签）写入切片时按逗号拆分
+		setSlice := b.compileSliceSetter(t, tf)
+		return func(field reflect.Value, value string) error {
+			return setSlice(field, splitValues([]string{value}, ","))
+		}
+	}
+	if t == timeType {
+		return func(field reflect.Value, value string) error {
+			parsed, err := tf.parseTime(value)
+			if err != nil {
+				return err
+			}
+			field.Set(reflect.ValueOf(parsed))
+			return nil
+		}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return func(field reflect.Value, value string) error {
+			field.SetString(value)
+			return nil
+		}
+	case reflect.Ptr:
+		elemType := t.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			// 检查新创建的指针的元素是否仍然是指针类型
+			return func(field reflect.Value, value string) error {
+				if field.IsNil() {
+					field.Set(reflect.New(elemType))
+				}
+				return fmt.Errorf("cannot set nested pointer field: %v", elemType)
+			}
+		}
+		elemSet := b.compileSetter(elemType, tf)
+		return func(field reflect.Value, value string) error {
+			if field.IsNil() {
+				// 创建一个新的指针，并设置为默认值
+				field.Set(reflect.New(elemType))
+			}
+			return elemSet(field.Elem(), value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// 按字段的位数解析，超出范围时返回错误而不是截断
+		return func(field reflect.Value, value string) error {
+			intValue, err := strconv.ParseInt(value, 10, t.Bits())
+			if err != nil {
+				return err
+			}
+			field.SetInt(intValue)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(field reflect.Value, value string) error {
+			uintValue, err := strconv.ParseUint(value, 10, t.Bits())
+			if err != nil {
+				return err
+			}
+			field.SetUint(uintValue)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(field reflect.Value, value string) error {
+			floatValue, err := strconv.ParseFloat(value, t.Bits())
+			if err != nil {
+				return err
+			}
+			field.SetFloat(floatValue)
+			return nil
+		}
+	case reflect.Bool:
+		return func(field reflect.Value, value string) error {
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			field.SetBool(boolValue)
+			return nil
+		}
+	case reflect.Struct:
+		return func(field reflect.Value, value string) error {
+			return fmt.Errorf("unsupported struct type: %v", t)
+		}
+	default:
+		return func(field reflect.Value, value string) error {
+			return fmt.Errorf("unsupported field type: %v with default value", t)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package chttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type BenchBaseReq struct {
+	TraceId  *string `header:"traceId,omitempty" v:"required"`
+	Platform *string `header:"platform,omitempty" default:"whatsapp"`
+	ReqId    *string `header:"reqId,omitempty"`
+}
+
+type benchReq struct {
+	BenchBaseReq `cv:"true"`
+	Origin       *string    `url:"origin"`
+	StoreId      *string    `url:"storeId"`
+	UserId       *string    `json:"userId,omitempty" v:"required"`
+	TransferType *string    `json:"transferType,omitempty" default:"manual"`
+	Page         int        `param:"page" default:"1"`
+	Size         int        `param:"size" default:"20"`
+	Since        *time.Time `json:"since,omitempty"`
+	Tags         []string   `json:"tags"`
+}
+
+func newBenchRequest() *http.Request {
+	body := `{"userId":"u-1","since":"2024-06-01 08:00:00","tags":["a","b","c"]}`
+	req, _ := http.NewRequest("POST", "/stores?page=2", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceId", "trace-1")
+	return withURLParams(req, map[string]string{"origin": "web", "storeId": "s-1"})
+}
+
+func BenchmarkParseWithValidationJSON(b *testing.B) {
+	binder := NewBinder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		req := newBenchRequest()
+		b.StartTimer()
+		if _, _, err := Bind[benchReq](binder, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseWithValidationGET(b *testing.B) {
+	req, _ := http.NewRequest("GET", "/stores?page=2&size=50", nil)
+	req.Header.Set("traceId", "trace-1")
+	type getReq struct {
+		BenchBaseReq `cv:"true"`
+		Page         int     `param:"page" default:"1"`
+		Size         int     `param:"size" default:"20"`
+		Keyword      *string `param:"keyword"`
+	}
+	binder := NewBinder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := BindWithValidation[getReq](binder, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCompilePlan 每次重新编译解析计划的开销，对比缓存后的 planFor
+func BenchmarkCompilePlan(b *testing.B) {
+	binder := NewBinder()
+	t := reflect.TypeOf(benchReq{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		binder.compilePlan(t)
+	}
+}
+
+func BenchmarkPlanFor(b *testing.B) {
+	binder := NewBinder()
+	t := reflect.TypeOf(benchReq{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		binder.planFor(t)
+	}
+}
+
+// TestPlanCached 测试同一类型的解析计划只编译一次
+func TestPlanCached(t *testing.T) {
+	b := NewBinder()
+	typ := reflect.TypeOf(benchReq{})
+	if b.planFor(typ) != b.planFor(typ) {
+		t.Error("expected planFor to return the cached plan")
+	}
+
+	plan := b.planFor(typ)
+	base := plan.fields[0]
+	if !base.recurse {
+		t.Fatalf("expected embedded BenchBaseReq to be recursive")
+	}
+	// 嵌套结构体共用同一类型的计划
+	if base.nested() != b.planFor(reflect.TypeOf(BenchBaseReq{})) {
+		t.Error("expected nested plan to be shared with planFor")
+	}
+	traceID := base.nested().fields[0]
+	if traceID.name != "TraceId" || traceID.header != "traceId" {
+		t.Errorf("unexpected nested field plan: name=%q header=%q", traceID.name, traceID.header)
+	}
+	if userID := plan.fields[3]; userID.jsonName != "userId" {
+		t.Errorf("expected jsonName userId, got %q", userID.jsonName)
+	}
+}
+
+// TestPlanSelfReferentialType 测试自引用类型不会在编译时无限递归
+func TestPlanSelfReferentialType(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Next *node  `json:"next"`
+	}
+	body := `{"name":"a","next":{"name":"b","next":{"name":"c"}}}`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	result, _, err := Valid[node](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Next == nil || result.Next.Next == nil || result.Next.Next.Name != "c" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+type deepNode struct {
+	Name string    `json:"name" xml:"name"`
+	Next *deepNode `json:"next" xml:"next"`
+}
+
+// deepNodeBody 返回嵌套 depth 层 next 的 JSON 请求体
+func deepNodeBody(depth int) string {
+	return strings.Repeat(`{"name":"n","next":`, depth) + `{"name":"last"}` + strings.Repeat("}", depth)
+}
+
+// TestPlanDeepNesting 测试深层嵌套的自引用类型共用一份计划，超过最大层数时返回 DecodeError
+func TestPlanDeepNesting(t *testing.T) {
+	b := NewBinder()
+	req, _ := http.NewRequest("POST", "/test", strings.NewReader(deepNodeBody(maxNestingDepth)))
+	result, _, err := Bind[deepNode](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	depth := 0
+	for node := &result; node.Next != nil; node = node.Next {
+		depth++
+	}
+	if depth != maxNestingDepth {
+		t.Errorf("expected depth %d, got %d", maxNestingDepth, depth)
+	}
+	plan := b.planFor(reflect.TypeOf(deepNode{}))
+	if plan.field("Next").nested() != plan {
+		t.Error("expected the self-referential field to reuse the type plan")
+	}
+
+	var decodeErr *DecodeError
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader(deepNodeBody(maxNestingDepth+1)))
+	if _, _, err := Bind[deepNode](b, req); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError for body nested deeper than %d, got %T: %v", maxNestingDepth, err, err)
+	}
+	xmlBody := strings.Repeat("<next>", maxNestingDepth+1) + strings.Repeat("</next>", maxNestingDepth+1)
+	req, _ = http.NewRequest("POST", "/test", strings.NewReader("<node>"+xmlBody+"</node>"))
+	req.Header.Set("Content-Type", "application/xml")
+	if _, _, err := Bind[deepNode](b, req); !errors.As(err, &decodeErr) {
+		t.Errorf("expected *DecodeError for XML nested deeper than %d, got %T: %v", maxNestingDepth, err, err)
+	}
+}
+
+// TestPlanConcurrentBind 测试并发绑定同一类型
+func TestPlanConcurrentBind(t *testing.T) {
+	b := NewBinder()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _, err := Bind[benchReq](b, newBenchRequest())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if *result.StoreId != "s-1" || *result.TransferType != "manual" || result.Page != 2 || result.Size != 20 {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,148 @@
+package chttp
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// ParamUnmarshaler 由需要自行解析 query、header、路径参数、表单、Cookie 和 default 标签字符串的类型实现，
+// 优先于 encoding.TextUnmarshaler
+type ParamUnmarshaler interface {
+	UnmarshalParam(value string) error
+}
+
+// TypeDecoder 将字符串转换为某个类型的值，通过 WithTypeDecoder 或 RegisterTypeDecoder 注册
+type TypeDecoder func(value string) (any, error)
+
+var (
+	paramUnmarshalerType = reflect.TypeOf((*ParamUnmarshaler)(nil)).Elem()
+	textUnmarshalerType  = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// builtinTypeDecoders 标准库中没有实现 encoding.TextUnmarshaler、需要特殊处理的类型，
+// net.IP、netip.Addr、netip.Prefix、big.Int 等实现了该接口的类型无需注册
+func builtinTypeDecoders() map[reflect.Type]TypeDecoder {
+	return map[reflect.Type]TypeDecoder{
+		reflect.TypeOf(time.Duration(0)): func(value string) (any, error) {
+			return time.ParseDuration(value)
+		},
+		reflect.TypeOf((*url.URL)(nil)): func(value string) (any, error) {
+			return url.Parse(value)
+		},
+		reflect.TypeOf(url.URL{}): func(value string) (any, error) {
+			u, err := url.Parse(value)
+			if err != nil {
+				return nil, err
+			}
+			return *u, nil
+		},
+		reflect.TypeOf([]byte(nil)): func(value string) (any, error) {
+			return decodeBase64(value)
+		},
+	}
+}
+
+// decodeBase64 解码标准或 URL 安全的 base64 字符串，末尾的填充可以省略
+func decodeBase64(value string) ([]byte, error) {
+	var err error
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		var decoded []byte
+		if decoded, err = enc.DecodeString(value); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, err
+}
+
+// WithTypeDecoder 为类型 T 注册字符串解析函数，优先于 ParamUnmarshaler 与 encoding.TextUnmarshaler
+func WithTypeDecoder[T any](fn func(value string) (T, error)) Option {
+	return func(b *Binder) {
+		b.typeDecoders[reflect.TypeOf((*T)(nil)).Elem()] = typeDecoderOf(fn)
+	}
+}
+
+// RegisterTypeDecoder 在 Binder 上为类型 t 注册字符串解析函数，应在开始处理请求之前完成
+func (b *Binder) RegisterTypeDecoder(t reflect.Type, fn TypeDecoder) {
+	b.typeDecoders[t] = fn
+	// 已编译的解析计划使用的是旧的赋值函数
+	b.plans.Range(func(key, _ any) bool {
+		b.plans.Delete(key)
+		return true
+	})
+	b.jsonKinds.Range(func(key, _ any) bool {
+		b.jsonKinds.Delete(key)
+		return true
+	})
+}
+
+// RegisterTypeDecoder 在默认 Binder 上为类型 T 注册字符串解析函数
+func RegisterTypeDecoder[T any](fn func(value string) (T, error)) {
+	defaultBinder.RegisterTypeDecoder(reflect.TypeOf((*T)(nil)).Elem(), typeDecoderOf(fn))
+}
+
+func typeDecoderOf[T any](fn func(value string) (T, error)) TypeDecoder {
+	return func(value string) (any, error) {
+		return fn(value)
+	}
+}
+
+// isScalar 类型是否按单个字符串整体解析：注册了 TypeDecoder，或实现了 ParamUnmarshaler / encoding.TextUnmarshaler。
+// 这类切片、结构体与 map 不会按多值或 deepObject 方式绑定
+func (b *Binder) isScalar(t reflect.Type) bool {
+	if _, ok := b.typeDecoders[t]; ok {
+		return true
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if _, ok := b.typeDecoders[t]; ok {
+			return true
+		}
+	}
+	return isUnmarshaler(t)
+}
+
+// isUnmarshaler *t 是否实现了 ParamUnmarshaler 或 encoding.TextUnmarshaler，time.Time 使用自己的解析规则
+func isUnmarshaler(t reflect.Type) bool {
+	if t == timeType {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return pt.Implements(paramUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// compileScalarSetter 为注册了 TypeDecoder 或实现了解析接口的类型生成赋值函数，其余类型返回 nil
+func (b *Binder) compileScalarSetter(t reflect.Type) func(field reflect.Value, value string) error {
+	if decode, ok := b.typeDecoders[t]; ok {
+		return func(field reflect.Value, value string) error {
+			decoded, err := decode(value)
+			if err != nil {
+				return err
+			}
+			if decoded == nil {
+				field.Set(reflect.Zero(t))
+				return nil
+			}
+			rv := reflect.ValueOf(decoded)
+			if !rv.Type().AssignableTo(t) {
+				return fmt.Errorf("type decoder returned %v, expected %v", rv.Type(), t)
+			}
+			field.Set(rv)
+			return nil
+		}
+	}
+	if !isUnmarshaler(t) {
+		return nil
+	}
+	if reflect.PointerTo(t).Implements(paramUnmarshalerType) {
+		return func(field reflect.Value, value string) error {
+			return field.Addr().Interface().(ParamUnmarshaler).UnmarshalParam(value)
+		}
+	}
+	return func(field reflect.Value, value string) error {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+}
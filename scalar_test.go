@@ -0,0 +1,126 @@
+package chttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testStatus 实现 ParamUnmarshaler 的枚举
+type testStatus int
+
+const (
+	testStatusOpen testStatus = iota + 1
+	testStatusClosed
+)
+
+func (s *testStatus) UnmarshalParam(value string) error {
+	switch value {
+	case "open":
+		*s = testStatusOpen
+	case "closed":
+		*s = testStatusClosed
+	default:
+		return fmt.Errorf("unknown status %q", value)
+	}
+	return nil
+}
+
+// testVersion 实现 encoding.TextUnmarshaler 的结构体
+type testVersion struct {
+	Major, Minor int
+}
+
+func (v *testVersion) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "v%d.%d", &v.Major, &v.Minor)
+	return err
+}
+
+// testMoney 通过 TypeDecoder 解析的类型
+type testMoney struct {
+	Cents int64
+}
+
+// testCSV 实现 encoding.TextUnmarshaler 的切片，整体解析而不是按多值绑定
+type testCSV []string
+
+func (c *testCSV) UnmarshalText(text []byte) error {
+	*c = strings.Split(string(text), "-")
+	return nil
+}
+
+func parseTestMoney(value string) (testMoney, error) {
+	yuan, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return testMoney{}, err
+	}
+	return testMoney{Cents: int64(yuan * 100)}, nil
+}
+
+// TestScalarUnmarshalers 测试 ParamUnmarshaler、encoding.TextUnmarshaler 与 TypeDecoder
+func TestScalarUnmarshalers(t *testing.T) {
+	type testStruct struct {
+		Status   testStatus   `param:"status"`
+		Statuses []testStatus `param:"statuses"`
+		Version  *testVersion `header:"X-Version"`
+		Min      testVersion  `param:"min" default:"v1.2"`
+		Price    testMoney    `param:"price"`
+		PricePtr *testMoney   `url:"price"`
+		Parts    testCSV      `param:"parts"`
+	}
+
+	b := NewBinder(WithTypeDecoder(parseTestMoney))
+	req, _ := http.NewRequest("GET", "/test?status=closed&statuses=open&statuses=closed&price=9.99&parts=a-b", nil)
+	req.Header.Set("X-Version", "v2.5")
+	req = withURLParams(req, map[string]string{"price": "1.5"})
+
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != testStatusClosed || !reflect.DeepEqual(result.Statuses, []testStatus{testStatusOpen, testStatusClosed}) {
+		t.Errorf("unexpected statuses: %v %v", result.Status, result.Statuses)
+	}
+	if result.Version == nil || *result.Version != (testVersion{2, 5}) || result.Min != (testVersion{1, 2}) {
+		t.Errorf("unexpected versions: %v %v", result.Version, result.Min)
+	}
+	if result.Price.Cents != 999 || result.PricePtr == nil || result.PricePtr.Cents != 150 {
+		t.Errorf("unexpected prices: %v %v", result.Price, result.PricePtr)
+	}
+	if !reflect.DeepEqual(result.Parts, testCSV{"a", "b"}) {
+		t.Errorf("unexpected Parts: %v", result.Parts)
+	}
+
+	req, _ = http.NewRequest("GET", "/test?status=pending", nil)
+	_, _, err = Bind[testStruct](b, req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "status" || !strings.Contains(err.Error(), `unknown status "pending"`) {
+		t.Errorf("expected *FieldConversionError, got %T: %v", err, err)
+	}
+}
+
+// TestRegisterTypeDecoder 测试在已缓存解析计划后注册 TypeDecoder
+func TestRegisterTypeDecoder(t *testing.T) {
+	type testStruct struct {
+		Price testMoney `header:"X-Price"`
+	}
+
+	b := NewBinder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Price", "2")
+	if _, _, err := Bind[testStruct](b, req); err == nil {
+		t.Fatal("expected error before registering decoder")
+	}
+
+	b.RegisterTypeDecoder(reflect.TypeOf(testMoney{}), func(value string) (any, error) {
+		return parseTestMoney(value)
+	})
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil || result.Price.Cents != 200 {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
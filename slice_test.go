@@ -0,0 +1,134 @@
+package chttp
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestSliceBinding 测试切片字段从重复的键、分隔符和多值 header 绑定
+func TestSliceBinding(t *testing.T) {
+	type testStruct struct {
+		Ids      []int     `param:"id"`
+		Tags     []string  `param:"tags,explode=false"`
+		Codes    []string  `param:"codes,sep=pipe"`
+		Scores   []*int    `param:"score"`
+		Accept   []string  `header:"Accept"`
+		Langs    []string  `header:"X-Lang"`
+		Ratios   []float64 `param:"ratio" default:"0.5,1.5"`
+		Statuses []string  `param:"status" v:"omitempty,dive,oneof=open closed"`
+		Empty    []int     `param:"empty"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?id=1&id=2&id=3&tags=a,b,,c&codes=x|y&score=7&score=8&status=open&status=closed", nil)
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Set("X-Lang", "zh, en")
+
+	result, parserResult, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parserResult != ParserResultSuccess {
+		t.Fatalf("expected ParserResultSuccess, got %v", parserResult)
+	}
+	checks := []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{"Ids", result.Ids, []int{1, 2, 3}},
+		{"Tags", result.Tags, []string{"a", "b", "c"}},
+		{"Codes", result.Codes, []string{"x", "y"}},
+		{"Accept", result.Accept, []string{"text/html", "application/json"}},
+		{"Langs", result.Langs, []string{"zh", "en"}},
+		{"Ratios", result.Ratios, []float64{0.5, 1.5}},
+		{"Statuses", result.Statuses, []string{"open", "closed"}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.expected) {
+			t.Errorf("expected %s to be %v, got %v", c.name, c.expected, c.got)
+		}
+	}
+	if len(result.Scores) != 2 || *result.Scores[0] != 7 || *result.Scores[1] != 8 {
+		t.Errorf("unexpected Scores: %v", result.Scores)
+	}
+	if result.Empty != nil {
+		t.Errorf("expected Empty to be nil, got %v", result.Empty)
+	}
+}
+
+// TestSliceFormBinding 测试表单中重复的键绑定到切片
+func TestSliceFormBinding(t *testing.T) {
+	type testStruct struct {
+		Roles []string `form:"role" v:"required,min=2"`
+	}
+
+	req := newFormRequest("/test", url.Values{"role": {"admin", "editor"}})
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Roles, []string{"admin", "editor"}) {
+		t.Errorf("unexpected Roles: %v", result.Roles)
+	}
+
+	req = newFormRequest("/test", url.Values{"role": {"admin"}})
+	_, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Error() != "form role must be at least 2" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestSliceErrors 测试切片元素的转换与校验错误
+func TestSliceErrors(t *testing.T) {
+	type testStruct struct {
+		Ids []int `param:"id"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?id=1&id=x", nil)
+	_, _, err := Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) {
+		t.Fatalf("expected *FieldConversionError, got %T: %v", err, err)
+	}
+	if conversionErr.Source != SourceQuery || conversionErr.Name != "id" || conversionErr.Value != "1,x" {
+		t.Errorf("unexpected conversion error: %+v", conversionErr)
+	}
+
+	type diveStruct struct {
+		Statuses []string `param:"status" v:"dive,oneof=open closed"`
+	}
+	req, _ = http.NewRequest("GET", "/test?status=open&status=pending", nil)
+	_, parserResult, err := Valid[diveStruct](req)
+	if parserResult != ParserResultNotVerified {
+		t.Fatalf("expected ParserResultNotVerified, got %v: %v", parserResult, err)
+	}
+}
+
+// TestParseParamTag 测试参数标签选项解析
+func TestParseParamTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		explode bool
+		name    string
+		sep     string
+	}{
+		{"ids", true, "ids", ""},
+		{"ids", false, "ids", ","},
+		{"ids,explode=false", true, "ids", ","},
+		{"ids,explode=true", false, "ids", ""},
+		{"ids,sep=pipe", true, "ids", "|"},
+		{"ids,sep=space", true, "ids", " "},
+		{"ids,sep=;", true, "ids", ";"},
+	}
+	for _, tt := range tests {
+		name, sep := parseParamTag(tt.tag, tt.explode)
+		if name != tt.name || sep != tt.sep {
+			t.Errorf("parseParamTag(%q, %v) = %q, %q; expected %q, %q", tt.tag, tt.explode, name, sep, tt.name, tt.sep)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package chttp
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestStdlibScalarTypes 测试标准库常用类型从 query、header、路径参数与 default 标签绑定
+func TestStdlibScalarTypes(t *testing.T) {
+	type testStruct struct {
+		Timeout  time.Duration   `param:"timeout"`
+		Interval *time.Duration  `header:"X-Interval"`
+		Retry    time.Duration   `param:"retry" default:"1m30s"`
+		IP       net.IP          `header:"X-Real-IP"`
+		Addr     netip.Addr      `param:"addr"`
+		Prefix   netip.Prefix    `url:"prefix"`
+		Callback *url.URL        `param:"callback"`
+		Home     url.URL         `param:"home" default:"https://example.com/"`
+		Amount   big.Int         `param:"amount"`
+		Balance  *big.Int        `param:"balance"`
+		Token    []byte          `param:"token"`
+		Delays   []time.Duration `param:"delay"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?timeout=30s&addr=::1&callback=https://a.com/cb?x=1&amount=123456789012345678901234567890&balance=-5&token=aGVsbG8&delay=1s&delay=2s", nil)
+	req.Header.Set("X-Interval", "500ms")
+	req.Header.Set("X-Real-IP", "10.0.0.1")
+	req = withURLParams(req, map[string]string{"prefix": "192.168.0.0/16"})
+
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Timeout != 30*time.Second || result.Interval == nil || *result.Interval != 500*time.Millisecond || result.Retry != 90*time.Second {
+		t.Errorf("unexpected durations: %v %v %v", result.Timeout, result.Interval, result.Retry)
+	}
+	if !result.IP.Equal(net.ParseIP("10.0.0.1")) || result.Addr != netip.MustParseAddr("::1") || result.Prefix != netip.MustParsePrefix("192.168.0.0/16") {
+		t.Errorf("unexpected addresses: %v %v %v", result.IP, result.Addr, result.Prefix)
+	}
+	if result.Callback == nil || result.Callback.Host != "a.com" || result.Home.Host != "example.com" {
+		t.Errorf("unexpected urls: %v %v", result.Callback, result.Home)
+	}
+	if result.Amount.String() != "123456789012345678901234567890" || result.Balance == nil || result.Balance.Int64() != -5 {
+		t.Errorf("unexpected big ints: %v %v", &result.Amount, result.Balance)
+	}
+	if string(result.Token) != "hello" {
+		t.Errorf("unexpected Token: %q", result.Token)
+	}
+	if len(result.Delays) != 2 || result.Delays[1] != 2*time.Second {
+		t.Errorf("unexpected Delays: %v", result.Delays)
+	}
+
+	req, _ = http.NewRequest("GET", "/test?timeout=30", nil)
+	_, _, err = Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "timeout" {
+		t.Errorf("expected *FieldConversionError for timeout, got %T: %v", err, err)
+	}
+}
+
+// TestStdlibScalarTypesFromJSON 测试请求体中以字符串表示的标准库类型
+func TestStdlibScalarTypesFromJSON(t *testing.T) {
+	type testStruct struct {
+		Timeout  time.Duration `json:"timeout"`
+		Interval time.Duration `json:"interval"`
+		Callback *url.URL      `json:"callback"`
+		Addr     netip.Addr    `json:"addr"`
+		Token    []byte        `json:"token"`
+		Name     string        `json:"name"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"timeout":"1h","interval":1000000000,"callback":"https://a.com/cb","addr":"127.0.0.1","token":"aGk=","name":"n"}`))
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Timeout != time.Hour || result.Interval != time.Second || result.Callback == nil || result.Callback.Host != "a.com" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Addr != netip.MustParseAddr("127.0.0.1") || string(result.Token) != "hi" || result.Name != "n" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"timeout":"soon"}`))
+	_, _, err = Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Source != SourceBody || conversionErr.Name != "timeout" {
+		t.Errorf("expected body *FieldConversionError, got %T: %v", err, err)
+	}
+}
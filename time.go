@@ -0,0 +1,142 @@
+package chttp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeFormat 时间字段的解析规则，由 Binder 的默认配置与字段的 time 标签合并而来
+type timeFormat struct {
+	layouts []string
+	unit    string         // unix、unixms、unixus、unixns，设置后只接受该精度的时间戳
+	loc     *time.Location // 不带时区的格式与时间戳使用的时区，nil 时格式按 UTC、时间戳按本地时区
+	strict  bool           // 严格模式：不猜测时间戳精度，输入匹配多个格式且结果不同时报错
+}
+
+// namedTimeLayouts time 标签中可以使用的格式名称
+var namedTimeLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// epochUnits 时间戳精度对应的转换函数
+var epochUnits = map[string]func(ts int64) time.Time{
+	"unix":   func(ts int64) time.Time { return time.Unix(ts, 0) },
+	"unixms": time.UnixMilli,
+	"unixus": time.UnixMicro,
+	"unixns": func(ts int64) time.Time { return time.Unix(0, ts) },
+}
+
+// WithTimeLocation 设置不带时区的时间格式与时间戳使用的时区
+func WithTimeLocation(loc *time.Location) Option {
+	return func(b *Binder) {
+		b.timeLocation = loc
+	}
+}
+
+// WithStrictTime 开启严格的时间解析：纯数字只有在 time 标签声明了精度（如 unixms）时才按时间戳解析，
+// 同一个输入匹配多个格式且结果不同时返回错误
+func WithStrictTime() Option {
+	return func(b *Binder) {
+		b.strictTime = true
+	}
+}
+
+// parseTimeTag 解析形如 "2006-01-02|2006/01/02,loc=Asia/Shanghai,strict" 或 "unixms" 的 time 标签，
+// 未指定的部分沿用 def。格式本身可以包含逗号，例如 RFC1123
+func parseTimeTag(tag string, def *timeFormat) (*timeFormat, error) {
+	tf := *def
+	var layout []string
+	for _, part := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "loc":
+			loc, err := time.LoadLocation(value)
+			if err != nil {
+				return nil, err
+			}
+			tf.loc = loc
+		case "strict":
+			tf.strict = value != "false"
+		default:
+			layout = append(layout, part)
+		}
+	}
+	if len(layout) == 0 {
+		return &tf, nil
+	}
+	tf.layouts = nil
+	for _, l := range strings.Split(strings.Join(layout, ","), "|") {
+		if _, ok := epochUnits[l]; ok {
+			tf.unit = l
+			continue
+		}
+		if named, ok := namedTimeLayouts[l]; ok {
+			l = named
+		}
+		tf.layouts = append(tf.layouts, l)
+	}
+	return &tf, nil
+}
+
+// parseTime 按解析规则依次尝试各个格式，最后尝试时间戳
+func (tf *timeFormat) parseTime(value string) (time.Time, error) {
+	loc := tf.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	var parsed time.Time
+	matched := false
+	for _, layout := range tf.layouts {
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if !tf.strict {
+			return t, nil
+		}
+		if matched && !t.Equal(parsed) {
+			return time.Time{}, fmt.Errorf("ambiguous time %q matches layouts with different results", value)
+		}
+		parsed, matched = t, true
+	}
+	if matched {
+		return parsed, nil
+	}
+
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		unit := tf.unit
+		if unit == "" {
+			if tf.strict {
+				return time.Time{}, fmt.Errorf("ambiguous timestamp %q: declare its unit in the time tag", value)
+			}
+			unit = guessEpochUnit(strings.TrimPrefix(value, "-"))
+		}
+		t := epochUnits[unit](ts)
+		if tf.loc != nil {
+			t = t.In(tf.loc)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("无法解析时间格式: %s", value)
+}
+
+// guessEpochUnit 按位数猜测时间戳精度：10 位以内为秒，13 位以内为毫秒，16 位以内为微秒，更长为纳秒
+func guessEpochUnit(digits string) string {
+	switch {
+	case len(digits) <= 10:
+		return "unix"
+	case len(digits) <= 13:
+		return "unixms"
+	case len(digits) <= 16:
+		return "unixus"
+	}
+	return "unixns"
+}
@@ -0,0 +1,203 @@
+package chttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestTimeTag 测试 time 标签指定格式、时区与时间戳精度
+func TestTimeTag(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	type testStruct struct {
+		Day      time.Time   `param:"day" time:"2006-01-02,loc=Asia/Shanghai"`
+		Alt      time.Time   `param:"alt" time:"02/01/2006|2006.01.02"`
+		Created  *time.Time  `param:"created" time:"unixms"`
+		Expires  time.Time   `header:"Expires" time:"RFC1123"`
+		Seen     []time.Time `param:"seen" time:"unix"`
+		Deadline time.Time   `param:"deadline" time:"DateTime,loc=Asia/Shanghai" default:"2030-01-01 00:00:00"`
+	}
+
+	req, _ := http.NewRequest("GET", "/test?day=2024-03-01&alt=2024.05.06&created=1700000000123&seen=1&seen=2", nil)
+	req.Header.Set("Expires", "Mon, 02 Jan 2006 15:04:05 GMT")
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, shanghai)) || result.Day.Location().String() != "Asia/Shanghai" {
+		t.Errorf("unexpected Day: %v", result.Day)
+	}
+	if !result.Alt.Equal(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Alt: %v", result.Alt)
+	}
+	if result.Created == nil || result.Created.UnixMilli() != 1700000000123 {
+		t.Errorf("unexpected Created: %v", result.Created)
+	}
+	if result.Expires.Unix() != 1136214245 {
+		t.Errorf("unexpected Expires: %v", result.Expires)
+	}
+	if len(result.Seen) != 2 || result.Seen[1].Unix() != 2 {
+		t.Errorf("unexpected Seen: %v", result.Seen)
+	}
+	if !result.Deadline.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, shanghai)) {
+		t.Errorf("unexpected Deadline: %v", result.Deadline)
+	}
+
+	// 声明了格式的字段不再接受其他格式
+	req, _ = http.NewRequest("GET", "/test?day=2024/03/01", nil)
+	_, _, err = Valid[testStruct](req)
+	var conversionErr *FieldConversionError
+	if !errors.As(err, &conversionErr) || conversionErr.Name != "day" {
+		t.Errorf("expected *FieldConversionError for day, got %T: %v", err, err)
+	}
+}
+
+// TestInvalidTimeTag 测试无法解析的 time 标签返回 TagError，嵌套结构体中的同样在绑定前报告
+func TestInvalidTimeTag(t *testing.T) {
+	type Window struct {
+		From time.Time `json:"from" time:"2006-01-02,loc=Mars/Olympus"`
+	}
+	type testStruct struct {
+		Name   string  `json:"name"`
+		Window *Window `json:"window"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"a"}`))
+	_, _, err := Valid[testStruct](req)
+	var tagErr *TagError
+	if !errors.As(err, &tagErr) || tagErr.Field != "From" || tagErr.Tag != "time" || tagErr.Value != "2006-01-02,loc=Mars/Olympus" {
+		t.Fatalf("expected *TagError for From, got %T: %v", err, err)
+	}
+	if StatusCode(err) != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", StatusCode(err))
+	}
+
+	req, _ = http.NewRequest("GET", "/test", nil)
+	if _, _, err := Valid[[]Window](req); !errors.As(err, &tagErr) {
+		t.Errorf("expected *TagError for []Window, got %T: %v", err, err)
+	}
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`{"from":"2024-01-02"}`))
+	if _, err := ReadRequestBody[Window](req); !errors.As(err, &tagErr) {
+		t.Errorf("expected ReadRequestBody to return *TagError, got %T: %v", err, err)
+	}
+}
+
+// TestTimeTagJSON 测试 time 标签作用于请求体中的时间字段
+func TestTimeTagJSON(t *testing.T) {
+	type testStruct struct {
+		Day     time.Time `json:"day" time:"2006-01-02,loc=Asia/Shanghai"`
+		Created time.Time `json:"created" time:"unixms"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"day":"2024-03-01","created":"1700000000123"}`))
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Day.Format(time.RFC3339) != "2024-03-01T00:00:00+08:00" || result.Created.UnixMilli() != 1700000000123 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+// TestTimeBinderOptions 测试 Binder 级别的时区、格式与严格模式
+func TestTimeBinderOptions(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	type testStruct struct {
+		At time.Time `param:"at"`
+	}
+
+	b := NewBinder(WithTimeLocation(tokyo), WithTimeFormats("2006-01-02 15:04"))
+	req, _ := http.NewRequest("GET", "/test?at=2024-01-02+03:04", nil)
+	result, _, err := Bind[testStruct](b, req)
+	if err != nil || !result.At.Equal(time.Date(2024, 1, 2, 3, 4, 0, 0, tokyo)) {
+		t.Errorf("unexpected result: %v, %v", result.At, err)
+	}
+	req, _ = http.NewRequest("GET", "/test?at=1700000000", nil)
+	if result, _, _ = Bind[testStruct](b, req); result.At.Location() != tokyo || result.At.Unix() != 1700000000 {
+		t.Errorf("expected timestamp in Asia/Tokyo, got %v", result.At)
+	}
+
+	strict := NewBinder(WithStrictTime(), WithTimeFormats("01/02/2006", "02/01/2006"))
+	req, _ = http.NewRequest("GET", "/test?at=03/04/2024", nil)
+	if _, _, err = Bind[testStruct](strict, req); err == nil {
+		t.Error("expected ambiguous date to be rejected")
+	}
+	req, _ = http.NewRequest("GET", "/test?at=04/04/2024", nil)
+	if result, _, err = Bind[testStruct](strict, req); err != nil || result.At.Month() != time.April {
+		t.Errorf("expected unambiguous date to be accepted, got %v, %v", result.At, err)
+	}
+	req, _ = http.NewRequest("GET", "/test?at=1700000000", nil)
+	if _, _, err = Bind[testStruct](strict, req); err == nil {
+		t.Error("expected timestamp without unit to be rejected in strict mode")
+	}
+}
+
+// TestGuessEpochUnit 测试按位数猜测时间戳精度
+func TestGuessEpochUnit(t *testing.T) {
+	tf := &timeFormat{}
+	tests := map[string]int64{
+		"1700000000":          1700000000000000000,
+		"1700000000123":       1700000000123000000,
+		"1700000000123456":    1700000000123456000,
+		"1700000000123456789": 1700000000123456789,
+	}
+	for input, expected := range tests {
+		parsed, err := tf.parseTime(input)
+		if err != nil || parsed.UnixNano() != expected {
+			t.Errorf("parseTime(%q) = %v, %v; expected %d", input, parsed.UnixNano(), err, expected)
+		}
+	}
+}
+
+// TestJSONTimeErrors 测试请求体中无法解析的时间返回转换错误，数字时间戳可以解析
+func TestJSONTimeErrors(t *testing.T) {
+	type Meta struct {
+		Created time.Time `json:"created"`
+	}
+	type testStruct struct {
+		At      *time.Time `json:"at"`
+		Updated time.Time  `json:"updated"`
+		Meta    Meta       `json:"meta"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`{"at":1700000000,"updated":1700000000123,"meta":{"created":null}}`))
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.At == nil || result.At.Unix() != 1700000000 || result.Updated.UnixMilli() != 1700000000123 || !result.Meta.Created.IsZero() {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		key   string
+		value string
+	}{
+		{"invalid_string", `{"updated":"yesterday"}`, "Updated", "updated", "yesterday"},
+		{"nested", `{"meta":{"created":"2024-13-45"}}`, "Meta.Created", "meta.created", "2024-13-45"},
+		{"fraction", `{"at":1.5}`, "At", "at", "1.5"},
+		{"bool", `{"at":true}`, "At", "at", "true"},
+		{"after_valid_json", `{"updated":"2024-01-01T00:00:00Z","meta":{"created":"never"}}`, "Meta.Created", "meta.created", "never"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			_, _, err := Valid[testStruct](req)
+			var conversionErr *FieldConversionError
+			if !errors.As(err, &conversionErr) {
+				t.Fatalf("expected *FieldConversionError, got %T: %v", err, err)
+			}
+			if conversionErr.Source != SourceBody || conversionErr.Field != tt.field || conversionErr.Name != tt.key || conversionErr.Value != tt.value {
+				t.Errorf("unexpected conversion error: %+v", conversionErr)
+			}
+			if StatusCode(err) != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", StatusCode(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package chttp
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var phonePattern = regexp.MustCompile(`^1[3-9]\d{9}$`)
+
+// TestBinderRegisterValidation 测试注册自定义校验规则
+func TestBinderRegisterValidation(t *testing.T) {
+	type testStruct struct {
+		Phone string `param:"phone" v:"required,cnphone"`
+	}
+	b := NewBinder()
+	if err := b.RegisterValidation("cnphone", func(fl validator.FieldLevel) bool {
+		return phonePattern.MatchString(fl.Field().String())
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", "/test?phone=13800138000", nil)
+	if _, parserResult, err := Bind[testStruct](b, req); parserResult != ParserResultSuccess {
+		t.Errorf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+	req, _ = http.NewRequest("GET", "/test?phone=12345", nil)
+	if _, parserResult, _ := Bind[testStruct](b, req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+}
+
+// TestBinderRegisterAlias 测试注册校验规则别名
+func TestBinderRegisterAlias(t *testing.T) {
+	type testStruct struct {
+		TenantId string `header:"X-Tenant" v:"tenant"`
+	}
+	b := NewBinder()
+	b.RegisterAlias("tenant", "required,len=8,alphanum")
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Tenant", "tenant01")
+	if _, parserResult, err := Bind[testStruct](b, req); parserResult != ParserResultSuccess {
+		t.Errorf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+	req.Header.Set("X-Tenant", "t-1")
+	if _, parserResult, _ := Bind[testStruct](b, req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+}
+
+// TestBinderRegisterStructValidation 测试结构体级别的校验
+func TestBinderRegisterStructValidation(t *testing.T) {
+	type rangeReq struct {
+		From int `param:"from"`
+		To   int `param:"to"`
+	}
+	b := NewBinder()
+	b.RegisterStructValidation(func(sl validator.StructLevel) {
+		req := sl.Current().Interface().(rangeReq)
+		if req.From > req.To {
+			sl.ReportError(req.From, "From", "From", "ltefield", "To")
+		}
+	}, rangeReq{})
+
+	req, _ := http.NewRequest("GET", "/test?from=1&to=5", nil)
+	if _, parserResult, err := Bind[rangeReq](b, req); parserResult != ParserResultSuccess {
+		t.Errorf("expected ParserResultSuccess, got %v: %v", parserResult, err)
+	}
+	req, _ = http.NewRequest("GET", "/test?from=9&to=5", nil)
+	if _, parserResult, _ := Bind[rangeReq](b, req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+}
+
+// TestBinderValidatorIsShared 测试校验器在 Binder 内复用，且遵循自定义的校验标签
+func TestBinderValidatorIsShared(t *testing.T) {
+	b := NewBinder(WithTagNames(TagNames{Validate: "validate"}))
+	if b.Validator() != b.Validator() {
+		t.Error("expected the same validator instance")
+	}
+	type testStruct struct {
+		Name string `param:"name" validate:"required"`
+	}
+	req, _ := http.NewRequest("GET", "/test", nil)
+	if _, parserResult, _ := Bind[testStruct](b, req); parserResult != ParserResultNotVerified {
+		t.Errorf("expected ParserResultNotVerified, got %v", parserResult)
+	}
+}
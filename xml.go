@@ -0,0 +1,232 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const xmlMediaType = "application/xml"
+
+var xmlUnmarshalerType = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+
+// xmlBodyDecoder 默认的 XML 请求体解码器（application/xml、text/xml 及 +xml 后缀），使用 xml 标签
+type xmlBodyDecoder struct {
+	binder *Binder
+}
+
+// Decode 使用 encoding/xml 解码请求体，再遍历一次根元素的属性与子元素，记录请求体中出现过的字段路径
+func (d *xmlBodyDecoder) Decode(r io.Reader, v any, present map[string]bool) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return &DecodeError{MediaType: xmlMediaType, Err: err}
+	}
+	t := reflect.TypeOf(v).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	plan := d.binder.planFor(t)
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return scanXMLElement(dec, start, plan, "", 0, present)
+		}
+	}
+}
+
+// BodyKeyTag 请求体的键名使用 xml 标签
+func (d *xmlBodyDecoder) BodyKeyTag() string {
+	return "xml"
+}
+
+// xmlIndex 结构体在 XML 中的字段索引，嵌入结构体的字段提升到外层，外层同名字段优先
+type xmlIndex struct {
+	elems    map[string]*fieldPlan // 子元素名 -> 字段，"a>b" 形式的路径按完整路径索引
+	parents  map[string]bool       // "a>b" 形式路径中的中间元素
+	attrs    map[string]*fieldPlan // 属性名 -> 字段
+	chardata *fieldPlan            // ,chardata 或 ,cdata
+	innerxml *fieldPlan            // ,innerxml
+	any      *fieldPlan            // ,any，接收没有对应字段的子元素
+	paths    map[*fieldPlan]string // 字段在该结构体中的路径，提升到外层的字段带嵌入结构体的字段名
+}
+
+// xmlFields 按 xml 标签建立字段索引，规则与 encoding/xml 一致：未设置名称时使用 Go 字段名，忽略命名空间
+func (p *typePlan) xmlFields() *xmlIndex {
+	p.xmlOnce.Do(func() {
+		idx := &xmlIndex{
+			elems:   make(map[string]*fieldPlan),
+			parents: make(map[string]bool),
+			attrs:   make(map[string]*fieldPlan),
+			paths:   make(map[*fieldPlan]string),
+		}
+		var inline []*fieldPlan
+		for _, f := range p.fields {
+			xmlTag := f.tag.Get("xml")
+			if xmlTag == "-" || f.name == "XMLName" || f.ctx != "" {
+				continue
+			}
+			if f.anonymous && xmlTag == "" && (f.typ.Kind() == reflect.Struct || f.typ.Kind() == reflect.Ptr && f.settable) {
+				inline = append(inline, f)
+				continue
+			}
+			if !f.settable {
+				continue
+			}
+			name, opts, _ := strings.Cut(xmlTag, ",")
+			if i := strings.LastIndexByte(name, ' '); i >= 0 {
+				name = name[i+1:]
+			}
+			if name == "" {
+				name = f.name
+			}
+			idx.paths[f] = f.name
+			switch {
+			case hasTagOption(opts, "attr"):
+				idx.attrs[name] = f
+			case hasTagOption(opts, "chardata"), hasTagOption(opts, "cdata"):
+				idx.chardata = f
+			case hasTagOption(opts, "innerxml"):
+				idx.innerxml = f
+			case hasTagOption(opts, "any"):
+				idx.any = f
+			case hasTagOption(opts, "comment"):
+			default:
+				idx.elems[name] = f
+				parts := strings.Split(name, ">")
+				for i := 1; i < len(parts); i++ {
+					idx.parents[strings.Join(parts[:i], ">")] = true
+				}
+			}
+		}
+		for _, f := range inline {
+			nested := f.nested().xmlFields()
+			promote := func(child *fieldPlan) *fieldPlan {
+				if child != nil {
+					idx.paths[child] = f.name + "." + nested.paths[child]
+				}
+				return child
+			}
+			for name, child := range nested.elems {
+				if _, ok := idx.elems[name]; !ok {
+					idx.elems[name] = promote(child)
+				}
+			}
+			for name := range nested.parents {
+				idx.parents[name] = true
+			}
+			for name, child := range nested.attrs {
+				if _, ok := idx.attrs[name]; !ok {
+					idx.attrs[name] = promote(child)
+				}
+			}
+			if idx.chardata == nil {
+				idx.chardata = promote(nested.chardata)
+			}
+			if idx.innerxml == nil {
+				idx.innerxml = promote(nested.innerxml)
+			}
+			if idx.any == nil {
+				idx.any = promote(nested.any)
+			}
+		}
+		p.xmlIndex = idx
+	})
+	return p.xmlIndex
+}
+
+// hasTagOption 标签选项（逗号分隔）中是否包含 option
+func hasTagOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// scanXMLElement 记录元素 start 的属性及子元素对应的字段，读取到 start 的结束标签为止，
+// fieldPrefix 为该元素对应的结构体在根结构体中的字段路径，depth 为嵌套结构体的层数
+func scanXMLElement(dec *xml.Decoder, start xml.StartElement, plan *typePlan, fieldPrefix string, depth int, present map[string]bool) error {
+	if depth > maxNestingDepth {
+		return &DecodeError{MediaType: xmlMediaType, Err: fmt.Errorf("exceeded max nesting depth %d", maxNestingDepth)}
+	}
+	idx := plan.xmlFields()
+	for _, attr := range start.Attr {
+		if f := idx.attrs[attr.Name.Local]; f != nil {
+			present[fieldPrefix+idx.paths[f]] = true
+		}
+	}
+	return scanXMLChildren(dec, idx, "", fieldPrefix, depth, present)
+}
+
+// scanXMLChildren 遍历当前元素的内容，prefix 为 "a>b" 形式路径中已经匹配的中间元素
+func scanXMLChildren(dec *xml.Decoder, idx *xmlIndex, prefix, fieldPrefix string, depth int, present map[string]bool) error {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if idx.innerxml != nil {
+				present[fieldPrefix+idx.paths[idx.innerxml]] = true
+			}
+			key := tok.Name.Local
+			if prefix != "" {
+				key = prefix + ">" + key
+			}
+			if f := idx.elems[key]; f != nil {
+				path := fieldPrefix + idx.paths[f]
+				present[path] = true
+				if isXMLStruct(f.typ) {
+					if err := scanXMLElement(dec, tok, f.nested(), path+".", depth+1, present); err != nil {
+						return err
+					}
+					continue
+				}
+			} else if idx.parents[key] {
+				if err := scanXMLChildren(dec, idx, key, fieldPrefix, depth, present); err != nil {
+					return err
+				}
+				continue
+			} else if idx.any != nil && prefix == "" {
+				present[fieldPrefix+idx.paths[idx.any]] = true
+			}
+			_ = dec.Skip()
+		case xml.CharData:
+			if len(bytes.TrimSpace(tok)) == 0 {
+				continue
+			}
+			if idx.innerxml != nil {
+				present[fieldPrefix+idx.paths[idx.innerxml]] = true
+			}
+			if idx.chardata != nil && prefix == "" {
+				present[fieldPrefix+idx.paths[idx.chardata]] = true
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// isXMLStruct 字段（或其指针、切片元素）是否按结构体逐个解析子元素，自定义解码的类型与 time.Time 除外
+func isXMLStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return !pt.Implements(xmlUnmarshalerType) && !pt.Implements(textUnmarshalerType)
+}
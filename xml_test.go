@@ -0,0 +1,159 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestXMLBinding 测试 XML 请求体与 header、query、路径参数、默认值合并后校验
+func TestXMLBinding(t *testing.T) {
+	type Meta struct {
+		Channel string `xml:"channel,attr"`
+	}
+	type Line struct {
+		Sku string `xml:"sku,attr" v:"required"`
+		Qty int    `xml:"qty"`
+	}
+	type testStruct struct {
+		XMLName xml.Name `xml:"notify"`
+		Meta
+		TraceId  string `header:"traceId" xml:"traceId" v:"required"`
+		OrderId  string `url:"orderId" v:"required"`
+		Amount   int64  `xml:"amount" v:"gte=0"`
+		Retries  int    `xml:"retries" default:"3"`
+		Currency string `xml:"currency" default:"CNY"`
+		City     string `xml:"address>city"`
+		Lines    []Line `xml:"lines>line" v:"dive"`
+		Note     string `xml:",chardata"`
+	}
+
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<notify channel="alipay">
+	<traceId>from-body</traceId>
+	<amount>100</amount>
+	<retries>0</retries>
+	<address><city>Shenzhen</city></address>
+	<lines><line sku="a"><qty>2</qty></line><line sku="b"/></lines>
+</notify>`
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("traceId", "from-header")
+	req = withURLParams(req, map[string]string{"orderId": "o-1"})
+	result, _, err := Valid[testStruct](req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TraceId != "from-body" || result.OrderId != "o-1" || result.Amount != 100 || result.Channel != "alipay" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	// 请求体中显式传入的 0 不会被 default 覆盖，缺失的元素使用默认值
+	if result.Retries != 0 || result.Currency != "CNY" {
+		t.Errorf("unexpected defaults: retries=%d currency=%q", result.Retries, result.Currency)
+	}
+	if result.City != "Shenzhen" || len(result.Lines) != 2 || result.Lines[0].Qty != 2 {
+		t.Errorf("unexpected nested values: %+v", result)
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`<notify><lines><line/></lines></notify>`))
+	req.Header.Set("Content-Type", "text/xml")
+	req.Header.Set("traceId", "from-header")
+	req = withURLParams(req, map[string]string{"orderId": "o-1"})
+	result, _, err = Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 1 || validationErr.Errors[0].Field != "Lines[0].Sku" {
+		t.Errorf("expected validation error for Lines[0].Sku, got %v", err)
+	}
+	if result.TraceId != "from-header" {
+		t.Errorf("expected TraceId from header, got %q", result.TraceId)
+	}
+}
+
+// TestXMLPresence 测试 XML 中出现过的字段被记录为来自请求体
+func TestXMLPresence(t *testing.T) {
+	type Base struct {
+		Version string `xml:"version,attr"`
+		Sign    string `xml:"sign"`
+	}
+	type testStruct struct {
+		Base
+		Items struct {
+			Count int `xml:"count"`
+		} `xml:"items"`
+		Extra []string `xml:",any"`
+		Skip  string   `xml:"-"`
+	}
+
+	body := `<req version="2"><sign>s</sign><items><count>0</count></items><unknown>x</unknown><Skip>y</Skip></req>`
+	present := make(map[string]bool)
+	var result testStruct
+	if err := (&xmlBodyDecoder{binder: NewBinder()}).Decode(bytes.NewBufferString(body), &result, present); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, path := range []string{"Base.Version", "Base.Sign", "Items", "Items.Count", "Extra"} {
+		if !present[path] {
+			t.Errorf("expected %s to be present, got %v", path, present)
+		}
+	}
+	if present["Skip"] {
+		t.Errorf("expected Skip to be ignored, got %v", present)
+	}
+}
+
+// TestXMLErrors 测试无法解析的 XML 与 +xml 后缀
+func TestXMLErrors(t *testing.T) {
+	type testStruct struct {
+		Amount int `xml:"amount"`
+	}
+
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`<req><amount>ten</amount></req>`))
+	req.Header.Set("Content-Type", "application/xml")
+	_, _, err := Valid[testStruct](req)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.MediaType != "application/xml" || StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected *DecodeError, got %T: %v", err, err)
+	}
+
+	// 错误中的 media type 为请求实际使用的类型
+	for _, contentType := range []string{"text/xml; charset=utf-8", "application/soap+xml"} {
+		req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`<req><amount>ten</amount></req>`))
+		req.Header.Set("Content-Type", contentType)
+		_, _, err = Valid[testStruct](req)
+		if !errors.As(err, &decodeErr) || !strings.HasPrefix(contentType, decodeErr.MediaType) {
+			t.Errorf("%s: expected *DecodeError with the request media type, got %T: %v", contentType, err, err)
+		}
+	}
+
+	req, _ = http.NewRequest("POST", "/test", bytes.NewBufferString(`<req><amount>10</amount></req>`))
+	req.Header.Set("Content-Type", "application/soap+xml")
+	if result, _, err := Valid[testStruct](req); err != nil || result.Amount != 10 {
+		t.Errorf("unexpected result: %+v, %v", result, err)
+	}
+}
+
+// TestXMLValidationNames 测试 XML 请求体的校验错误按 xml 标签命名字段，没有 xml 标签时使用 Go 字段名
+func TestXMLValidationNames(t *testing.T) {
+	type Line struct {
+		Sku string `xml:"sku,attr" v:"required"`
+	}
+	type testStruct struct {
+		Amount int    `xml:"amount" v:"required"`
+		Lines  []Line `xml:"line" v:"dive"`
+		Note   string `v:"required"`
+	}
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(`<req><line sku="a"/><line/></req>`))
+	req.Header.Set("Content-Type", "application/xml")
+	_, _, err := Valid[testStruct](req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 3 {
+		t.Fatalf("expected 3 validation errors, got %v", err)
+	}
+	for i, name := range []string{"amount", "line[1].sku", "Note"} {
+		if fe := validationErr.Errors[i]; fe.Name != name || fe.Source != SourceBody {
+			t.Errorf("expected body %s, got %+v", name, fe)
+		}
+	}
+}